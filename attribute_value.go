@@ -1,15 +1,12 @@
 package sqldav
 
 import (
-	"database/sql"
+	"bytes"
 	"errors"
 	"fmt"
-	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
-	"github.com/iancoleman/strcase"
 	"reflect"
-	"regexp"
-	"strings"
+	"slices"
 )
 
 // ErrNestedStructHasIncompatibleAttributes occurs when the nested struct has incompatible attributes.
@@ -17,25 +14,7 @@ var ErrNestedStructHasIncompatibleAttributes = errors.New("nested struct has inc
 
 // AssignMapValueToReflectValue assigns the map type value to the reflect.Value
 func AssignMapValueToReflectValue(rt reflect.Type, rv reflect.Value, mv map[string]interface{}) error {
-	for i := 0; i < rt.NumField(); i++ {
-		tf := rt.Field(i)
-		vf := func() reflect.Value {
-			if rv.Kind() == reflect.Pointer {
-				return rv.Elem().Field(i)
-			}
-			return rv.Field(i)
-		}()
-		name := getColumnNameFromStructField(tf)
-		a, ok := mv[name]
-		if !ok {
-			continue
-		}
-		err := assignInterfaceValueToReflectValue(tf.Type, vf, a)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return defaultDecoder.Load().decodeStruct(rt, rv, mv)
 }
 
 // ErrDocumentAttributeValueIsIncompatible occurs when an incompatible conversion to following:
@@ -66,183 +45,132 @@ func ToDocumentAttributeValue[T DocumentAttributeMember](value interface{}) (T,
 	return nil, ErrDocumentAttributeValueIsIncompatible
 }
 
-// reXORMColumnName matches column name from xorm tag
-var reXORMColumnName = regexp.MustCompile(`'(.*?)'`)
-
-// getColumnNameFromStructField returns the column name from the struct field
-func getColumnNameFromStructField(sf reflect.StructField) string {
-	tag := sf.Tag
-	for _, value := range strings.Split(tag.Get("gorm"), ";") {
-		if value == "" {
-			continue
-		}
-		kv := strings.Split(value, ":")
-		if len(kv) != 2 {
-			continue
+// FromAttributeValue converts the types.AttributeValue to the Go value of the sqldav types.
+// It is the inverse of the conversion by Marshal for the following values:
+//   - *types.AttributeValueMemberS to string
+//   - *types.AttributeValueMemberN to Number
+//   - *types.AttributeValueMemberB to []byte
+//   - *types.AttributeValueMemberBOOL to bool
+//   - *types.AttributeValueMemberNULL to nil
+//   - *types.AttributeValueMemberSS to Set[string]
+//   - *types.AttributeValueMemberNS to Set[Number]
+//   - *types.AttributeValueMemberBS to Set[[]byte]
+//   - *types.AttributeValueMemberL to List
+//   - *types.AttributeValueMemberM to Map
+//
+// The elements of List and Map are converted recursively.
+// The slices are copied, so the result does not share memory with the types.AttributeValue.
+func FromAttributeValue(av types.AttributeValue) (interface{}, error) {
+	switch av := av.(type) {
+	case *types.AttributeValueMemberS:
+		return av.Value, nil
+	case *types.AttributeValueMemberN:
+		return Number(av.Value), nil
+	case *types.AttributeValueMemberB:
+		return bytes.Clone(av.Value), nil
+	case *types.AttributeValueMemberBOOL:
+		return av.Value, nil
+	case *types.AttributeValueMemberNULL:
+		return nil, nil
+	case *types.AttributeValueMemberSS:
+		return Set[string](slices.Clone(av.Value)), nil
+	case *types.AttributeValueMemberNS:
+		s := make(Set[Number], 0, len(av.Value))
+		for _, v := range av.Value {
+			s = append(s, Number(v))
+		}
+		return s, nil
+	case *types.AttributeValueMemberBS:
+		s := make(Set[[]byte], 0, len(av.Value))
+		for _, v := range av.Value {
+			s = append(s, bytes.Clone(v))
+		}
+		return s, nil
+	case *types.AttributeValueMemberL:
+		l, err := ListFromAttributeValue(av)
+		if err != nil {
+			return nil, err
 		}
-		switch kv[0] {
-		case "column":
-			return kv[1]
+		return l, nil
+	case *types.AttributeValueMemberM:
+		m, err := MapFromAttributeValue(av)
+		if err != nil {
+			return nil, err
 		}
+		return m, nil
 	}
-	if name := tag.Get("db"); name != "" {
-		return name
-	}
-	if xt := tag.Get("xorm"); xt != "" {
-		matches := reXORMColumnName.FindAllString(xt, 1)
-		if len(matches) > 0 {
-			return matches[1]
+	return nil, errors.Join(ErrFailedToCast, fmt.Errorf("unsupported attribute value %T", av))
+}
+
+// MapFromAttributeValue converts the *types.AttributeValueMemberM to a Map like FromAttributeValue.
+// Returns nil if the value is NULL.
+func MapFromAttributeValue(av types.AttributeValue) (Map, error) {
+	switch av := av.(type) {
+	case *types.AttributeValueMemberM:
+		m := make(Map, len(av.Value))
+		for k, v := range av.Value {
+			iv, err := FromAttributeValue(v)
+			if err != nil {
+				return nil, withDecodePath(newDecodeError(emptyInterfaceType, v, err), k, "")
+			}
+			m[k] = iv
 		}
+		return m, nil
+	case *types.AttributeValueMemberNULL:
+		return nil, nil
 	}
-	return strcase.ToSnake(sf.Name)
+	return nil, errors.Join(ErrFailedToCast, fmt.Errorf("incompatible Map and %T", av))
 }
 
-// assignInterfaceValueToReflectValue assigns the value to the reflect.Value
-func assignInterfaceValueToReflectValue(rt reflect.Type, rv reflect.Value, value interface{}) error {
-	if rv.CanAddr() {
-		switch sc := rv.Addr().Interface().(type) {
-		case sql.Scanner:
-			return sc.Scan(value)
-		}
-	} else {
-		switch sc := rv.Interface().(type) {
-		case sql.Scanner:
-			return sc.Scan(value)
+// ListFromAttributeValue converts the *types.AttributeValueMemberL to a List like FromAttributeValue.
+// Returns nil if the value is NULL.
+func ListFromAttributeValue(av types.AttributeValue) (List, error) {
+	switch av := av.(type) {
+	case *types.AttributeValueMemberL:
+		l := make(List, 0, len(av.Value))
+		for i, v := range av.Value {
+			iv, err := FromAttributeValue(v)
+			if err != nil {
+				return nil, withDecodePath(newDecodeError(emptyInterfaceType, v, err), indexSegment(i), "")
+			}
+			l = append(l, iv)
 		}
+		return l, nil
+	case *types.AttributeValueMemberNULL:
+		return nil, nil
 	}
-	switch rt.Kind() {
-	case reflect.String:
-		str, ok := value.(string)
-		if !ok {
-			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
-				fmt.Errorf("incompatible string and %T", value))
-		}
-		rv.SetString(str)
-	case reflect.Int:
-		f64, ok := value.(float64)
-		if !ok {
-			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
-				fmt.Errorf("incompatible int and %T", value))
-		}
-		rv.Set(reflect.ValueOf(int(f64)))
-	case reflect.Bool:
-		b, ok := value.(bool)
-		if !ok {
-			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
-				fmt.Errorf("incompatible bool and %T", value))
-		}
-		rv.SetBool(b)
-	case reflect.Float64:
-		f64, ok := value.(float64)
-		if !ok {
-			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
-				fmt.Errorf("incompatible float64 and %T", value))
-		}
-		rv.SetFloat(f64)
-	case reflect.Slice:
-		if rt.Elem().Kind() != reflect.Uint8 {
-			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
-				fmt.Errorf("incompatible []byte and %T", value))
-		}
-		b, ok := value.([]byte)
-		if !ok {
-			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
-				fmt.Errorf("incompatible []byte and %T", value))
-		}
-		rv.SetBytes(b)
-	case reflect.Struct:
-		mv, ok := value.(map[string]interface{})
-		if !ok {
-			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
-				fmt.Errorf("incompatible struct and %T", value))
-		}
-		err := AssignMapValueToReflectValue(rt, rv, mv)
-		if err != nil {
-			return err
-		}
-	case reflect.Pointer:
-		if value == nil {
-			return nil
-		}
-		rv.Set(reflect.New(rt.Elem()))
-		// NOTE: even return error, it will not be returned to the caller.
-		// Only expect the attribute to be nil.
-		assignInterfaceValueToReflectValue(rt.Elem(), rv.Elem(), value)
+	return nil, errors.Join(ErrFailedToCast, fmt.Errorf("incompatible List and %T", av))
+}
+
+// SetFromAttributeValue converts the *types.AttributeValueMemberSS, *types.AttributeValueMemberNS
+// or *types.AttributeValueMemberBS to a Set[T]. Numbers are converted without loss of precision.
+// Returns nil if the value is NULL.
+func SetFromAttributeValue[T SetSupportable](av types.AttributeValue) (Set[T], error) {
+	var s Set[T]
+	if av == nil {
+		return nil, errors.Join(ErrFailedToCast, fmt.Errorf("incompatible %T and %T", s, av))
+	}
+	if err := s.Scan(av); err != nil {
+		return nil, err
 	}
-	return nil
+	return s, nil
+}
+
+// NumberFromAttributeValue converts the *types.AttributeValueMemberN to a Number.
+func NumberFromAttributeValue(av types.AttributeValue) (Number, error) {
+	n, ok := av.(*types.AttributeValueMemberN)
+	if !ok {
+		return "", errors.Join(ErrValueIsIncompatibleOfNumber, fmt.Errorf("incompatible Number and %T", av))
+	}
+	return Number(n.Value), nil
+}
+
+// assignInterfaceValueToReflectValue assigns the value to the reflect.Value
+func assignInterfaceValueToReflectValue(rt reflect.Type, rv reflect.Value, value interface{}) error {
+	return defaultDecoder.Load().decode(rt, rv, value)
 }
 
 // toAttibuteValue converts the value to a types.AttributeValue
 func toAttibuteValue(value interface{}) (types.AttributeValue, error) {
-	switch value := value.(type) {
-	case List:
-		avs := make([]types.AttributeValue, 0, len(value))
-		for _, v := range value {
-			av, err := toAttibuteValue(v)
-			if err != nil {
-				return nil, err
-			}
-			avs = append(avs, av)
-		}
-		return &types.AttributeValueMemberL{Value: avs}, nil
-	case Map:
-		avm := make(map[string]types.AttributeValue)
-		for k, v := range value {
-			av, err := toAttibuteValue(v)
-			if err != nil {
-				return nil, err
-			}
-			avm[k] = av
-		}
-		return &types.AttributeValueMemberM{Value: avm}, nil
-	case Set[string]:
-		return &types.AttributeValueMemberSS{Value: value}, nil
-	case Set[int]:
-		ss := make([]string, 0, len(value))
-		for _, v := range value {
-			ss = append(ss, fmt.Sprintf("%v", v))
-		}
-		return &types.AttributeValueMemberNS{Value: ss}, nil
-	case Set[float64]:
-		ss := make([]string, 0, len(value))
-		for _, v := range value {
-			ss = append(ss, fmt.Sprintf("%v", v))
-		}
-		return &types.AttributeValueMemberNS{Value: ss}, nil
-	case Set[[]byte]:
-		return &types.AttributeValueMemberBS{Value: value}, nil
-	default:
-		rv := reflect.ValueOf(value)
-		switch rv.Kind() {
-		case reflect.Struct:
-			avm := make(map[string]types.AttributeValue)
-			for i := 0; i < rv.NumField(); i++ {
-				fv := rv.Field(i)
-				ft := rv.Type().Field(i)
-				if fv.CanInterface() {
-					av, err := toAttibuteValue(fv.Interface())
-					if err != nil {
-						return nil, err
-					}
-					avm[getColumnNameFromStructField(ft)] = av
-				} else if fv.CanAddr() {
-					av, err := toAttibuteValue(fv.Addr().Interface())
-					if err != nil {
-						return nil, err
-					}
-					avm[getColumnNameFromStructField(ft)] = av
-				}
-			}
-			return &types.AttributeValueMemberM{Value: avm}, nil
-		case reflect.Ptr:
-			if rv.IsNil() {
-				return &types.AttributeValueMemberNULL{}, nil
-			}
-			if !rv.CanAddr() {
-				return attributevalue.Marshal(value)
-			}
-			return toAttibuteValue(rv.Addr().Interface())
-		}
-		return attributevalue.Marshal(value)
-	}
+	return defaultEncoder.encode(value)
 }
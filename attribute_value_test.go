@@ -4,6 +4,9 @@ import (
 	"errors"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/google/go-cmp/cmp"
+	"math"
+	"math/rand"
+	"strconv"
 	"testing"
 )
 
@@ -581,3 +584,245 @@ func Test_toDocumentAttributeValue_Map(t *testing.T) {
 		})
 	}
 }
+
+func TestFromAttributeValue(t *testing.T) {
+	type test struct {
+		args    types.AttributeValue
+		want    interface{}
+		wantErr error
+	}
+	tests := map[string]test{
+		"happy-path/string": {
+			args: &types.AttributeValueMemberS{Value: "a"},
+			want: "a",
+		},
+		"happy-path/number": {
+			args: &types.AttributeValueMemberN{Value: "12345678901234567890.5"},
+			want: Number("12345678901234567890.5"),
+		},
+		"happy-path/binary": {
+			args: &types.AttributeValueMemberB{Value: []byte("a")},
+			want: []byte("a"),
+		},
+		"happy-path/bool": {
+			args: &types.AttributeValueMemberBOOL{Value: true},
+			want: true,
+		},
+		"happy-path/null": {
+			args: &types.AttributeValueMemberNULL{Value: true},
+			want: nil,
+		},
+		"happy-path/string-set": {
+			args: &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+			want: Set[string]{"a", "b"},
+		},
+		"happy-path/number-set": {
+			args: &types.AttributeValueMemberNS{Value: []string{"1", "2.5"}},
+			want: Set[Number]{"1", "2.5"},
+		},
+		"happy-path/binary-set": {
+			args: &types.AttributeValueMemberBS{Value: [][]byte{[]byte("a")}},
+			want: Set[[]byte]{[]byte("a")},
+		},
+		"happy-path/nested": {
+			args: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"l": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+					&types.AttributeValueMemberN{Value: "1"},
+					&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{}},
+				}},
+			}},
+			want: Map{"l": List{Number("1"), Map{}}},
+		},
+		"unhappy-path/nil": {
+			wantErr: ErrFailedToCast,
+		},
+		"unhappy-path/nested-nil": {
+			args:    &types.AttributeValueMemberL{Value: []types.AttributeValue{nil}},
+			wantErr: ErrFailedToCast,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := FromAttributeValue(tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FromAttributeValue() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("FromAttributeValue() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFromAttributeValue_ErrorPath(t *testing.T) {
+	_, err := FromAttributeValue(&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"l": &types.AttributeValueMemberL{Value: []types.AttributeValue{nil}},
+	}})
+	var de *DecodeError
+	if !errors.As(err, &de) || de.Path != "l[0]" {
+		t.Errorf("FromAttributeValue() error = %v, want the path l[0]", err)
+	}
+}
+
+func TestFromAttributeValue_Copy(t *testing.T) {
+	ss := &types.AttributeValueMemberSS{Value: []string{"a", "b"}}
+	bs := &types.AttributeValueMemberBS{Value: [][]byte{[]byte("a"), []byte("b")}}
+	sv, err := FromAttributeValue(ss)
+	if err != nil {
+		t.Fatalf("FromAttributeValue() error = %v", err)
+	}
+	s := sv.(Set[string])
+	s.Remove("a")
+	bv, err := FromAttributeValue(bs)
+	if err != nil {
+		t.Fatalf("FromAttributeValue() error = %v", err)
+	}
+	b := bv.(Set[[]byte])
+	b.Remove([]byte("a"))
+	if diff := cmp.Diff([]string{"a", "b"}, ss.Value); diff != "" {
+		t.Errorf("Set.Remove() modified the SS (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([][]byte{[]byte("a"), []byte("b")}, bs.Value); diff != "" {
+		t.Errorf("Set.Remove() modified the BS (-want +got):\n%s", diff)
+	}
+	bs.Value[1][0] = 'x'
+	if diff := cmp.Diff(Set[[]byte]{[]byte("b")}, b); diff != "" {
+		t.Errorf("modifying the BS modified the Set (-want +got):\n%s", diff)
+	}
+
+	bin := &types.AttributeValueMemberB{Value: []byte("a")}
+	v, err := FromAttributeValue(bin)
+	if err != nil {
+		t.Fatalf("FromAttributeValue() error = %v", err)
+	}
+	bin.Value[0] = 'x'
+	if diff := cmp.Diff([]byte("a"), v); diff != "" {
+		t.Errorf("modifying the B modified the result (-want +got):\n%s", diff)
+	}
+}
+
+func TestTypedFromAttributeValue(t *testing.T) {
+	m, err := MapFromAttributeValue(&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"a": &types.AttributeValueMemberBOOL{Value: true},
+	}})
+	if err != nil || !cmp.Equal(Map{"a": true}, m) {
+		t.Errorf("MapFromAttributeValue() = %v, %v", m, err)
+	}
+	if _, err := MapFromAttributeValue(&types.AttributeValueMemberL{}); !errors.Is(err, ErrFailedToCast) {
+		t.Errorf("MapFromAttributeValue() error = %v, want %v", err, ErrFailedToCast)
+	}
+	l, err := ListFromAttributeValue(&types.AttributeValueMemberNULL{Value: true})
+	if err != nil || l != nil {
+		t.Errorf("ListFromAttributeValue() = %v, %v, want nil", l, err)
+	}
+	if _, err := ListFromAttributeValue(&types.AttributeValueMemberM{}); !errors.Is(err, ErrFailedToCast) {
+		t.Errorf("ListFromAttributeValue() error = %v, want %v", err, ErrFailedToCast)
+	}
+	s, err := SetFromAttributeValue[uint64](&types.AttributeValueMemberNS{Value: []string{"18446744073709551615"}})
+	if err != nil || !cmp.Equal(Set[uint64]{math.MaxUint64}, s) {
+		t.Errorf("SetFromAttributeValue() = %v, %v", s, err)
+	}
+	if _, err := SetFromAttributeValue[string](&types.AttributeValueMemberNS{Value: []string{"1"}}); !errors.Is(err, ErrValueIsIncompatibleOfStringSlice) {
+		t.Errorf("SetFromAttributeValue() error = %v, want %v", err, ErrValueIsIncompatibleOfStringSlice)
+	}
+	n, err := NumberFromAttributeValue(&types.AttributeValueMemberN{Value: "1E+400"})
+	if err != nil || n != "1E+400" {
+		t.Errorf("NumberFromAttributeValue() = %v, %v", n, err)
+	}
+	if _, err := NumberFromAttributeValue(&types.AttributeValueMemberS{Value: "1"}); !errors.Is(err, ErrValueIsIncompatibleOfNumber) {
+		t.Errorf("NumberFromAttributeValue() error = %v, want %v", err, ErrValueIsIncompatibleOfNumber)
+	}
+}
+
+// randomDocument returns a random Go value that Marshal converts to a types.AttributeValue.
+// The sets are non-empty, de-duplicated and sorted, as Marshal canonicalizes them.
+func randomDocument(r *rand.Rand, depth int) interface{} {
+	kinds := 8
+	if depth > 0 {
+		kinds = 10
+	}
+	switch r.Intn(kinds) {
+	case 0:
+		return randomString(r)
+	case 1:
+		return Number(strconv.FormatInt(r.Int63n(2001)-1000, 10) + []string{"", ".5", "E+3"}[r.Intn(3)])
+	case 2:
+		return []byte(randomString(r))
+	case 3:
+		return r.Intn(2) == 0
+	case 4:
+		return nil
+	case 5:
+		s := Set[string]{}
+		for i, n := 0, r.Intn(4)+1; i < n; i++ {
+			s = append(s, randomString(r))
+		}
+		return canonicalSet(s)
+	case 6:
+		s := Set[Number]{}
+		for i, n := 0, r.Intn(4)+1; i < n; i++ {
+			s = append(s, Number(strconv.FormatInt(r.Int63n(2001)-1000, 10)))
+		}
+		return canonicalSet(s)
+	case 7:
+		s := Set[[]byte]{}
+		for i, n := 0, r.Intn(4)+1; i < n; i++ {
+			s = append(s, []byte(randomString(r)))
+		}
+		return canonicalSet(s)
+	case 8:
+		l := List{}
+		for i, n := 0, r.Intn(4); i < n; i++ {
+			l = append(l, randomDocument(r, depth-1))
+		}
+		return l
+	}
+	m := Map{}
+	for i, n := 0, r.Intn(4); i < n; i++ {
+		m[randomString(r)] = randomDocument(r, depth-1)
+	}
+	return m
+}
+
+// randomString returns a random non-empty string.
+func randomString(r *rand.Rand) string {
+	const letters = "abcxyz0123"
+	b := make([]byte, r.Intn(5)+1)
+	for i := range b {
+		b[i] = letters[r.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+func canonicalSet[T SetSupportable](s Set[T]) Set[T] {
+	c, err := s.canonicalize()
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func TestFromAttributeValue_RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		v := randomDocument(r, 3)
+		av, err := Marshal(v)
+		if err != nil {
+			t.Fatalf("Marshal(%#v) error = %v", v, err)
+		}
+		got, err := FromAttributeValue(av)
+		if err != nil {
+			t.Fatalf("FromAttributeValue() error = %v", err)
+		}
+		if diff := cmp.Diff(v, got); diff != "" {
+			t.Fatalf("FromAttributeValue(Marshal(v)) mismatch (-want +got):\n%s", diff)
+		}
+		again, err := Marshal(got)
+		if err != nil {
+			t.Fatalf("Marshal(%#v) error = %v", got, err)
+		}
+		if diff := cmp.Diff(av, again, attributeValueCmpOptions()...); diff != "" {
+			t.Fatalf("Marshal(FromAttributeValue(av)) mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,484 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/miyamo2/sqldav/internal/structtag"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrTypeNotFound       = errors.New("type not found")
+	ErrUnsupportedType    = errors.New("unsupported type")
+	ErrUnsupportedPackage = errors.New("unsupported package")
+)
+
+// config is the configuration of the generator.
+type config struct {
+	// types are the names of the struct types to generate the methods for.
+	types []string
+	// tagKeys are the struct tag keys that specify the attribute name, in order of precedence.
+	tagKeys []string
+	// attributeName resolves the attribute name of the struct fields that have no tag.
+	attributeName func(string) string
+	// output is the base name of the generated file, which is excluded from the input.
+	output string
+}
+
+// pkg is the parsed package.
+type pkg struct {
+	name string
+	// structs are the struct types declared in the package.
+	structs map[string]*ast.StructType
+	// methods are the method names declared in the package per receiver type.
+	methods map[string]map[string]struct{}
+}
+
+// parsePackage parses the Go files in the directory, except the test files and the output.
+func parsePackage(dir string, c config) (*pkg, error) {
+	fset := token.NewFileSet()
+	filter := func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != c.output
+	}
+	pkgs, err := parser.ParseDir(fset, dir, filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, errors.Join(ErrUnsupportedPackage, fmt.Errorf("%d packages in %s", len(pkgs), dir))
+	}
+	p := &pkg{
+		structs: map[string]*ast.StructType{},
+		methods: map[string]map[string]struct{}{},
+	}
+	for name, astPkg := range pkgs {
+		p.name = name
+		for _, f := range astPkg.Files {
+			for _, decl := range f.Decls {
+				switch decl := decl.(type) {
+				case *ast.GenDecl:
+					for _, spec := range decl.Specs {
+						ts, ok := spec.(*ast.TypeSpec)
+						if !ok || ts.TypeParams != nil {
+							continue
+						}
+						if st, ok := ts.Type.(*ast.StructType); ok {
+							p.structs[ts.Name.Name] = st
+						}
+					}
+				case *ast.FuncDecl:
+					if decl.Recv == nil || len(decl.Recv.List) == 0 {
+						continue
+					}
+					recv := typeName(decl.Recv.List[0].Type)
+					if p.methods[recv] == nil {
+						p.methods[recv] = map[string]struct{}{}
+					}
+					p.methods[recv][decl.Name.Name] = struct{}{}
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+// typeName returns the name of the named type, dereferencing the pointer. e.g. `T` for `*pkg.T`
+func typeName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.StarExpr:
+		return typeName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.IndexExpr:
+		return typeName(expr.X)
+	case *ast.IndexListExpr:
+		return typeName(expr.X)
+	}
+	return ""
+}
+
+// field is the struct field converted to an attribute, including the ones promoted from embedded structs.
+type field struct {
+	index []int
+	tag   structtag.Tag
+	// selector is the path of the Go field from the struct. e.g. `Audit.CreatedBy`
+	selector string
+	// goField is the name of the field qualified by the struct type. e.g. `User.CreatedBy`
+	goField string
+	// kind is the predeclared type of the field, or empty if the field is converted by the reflective path.
+	kind string
+}
+
+// customCodecMethods are the methods of the types that convert themselves.
+var customCodecMethods = []string{
+	"Scan", "Value",
+	"MarshalAttributeValue", "UnmarshalAttributeValue",
+	"MarshalDynamoDBAttributeValue", "UnmarshalDynamoDBAttributeValue",
+}
+
+// hasCustomCodec reports whether the local type converts itself, and therefore is not flattened.
+func (g *generator) hasCustomCodec(name string) bool {
+	if slices.Contains(g.config.types, name) {
+		return true
+	}
+	methods := g.pkg.methods[name]
+	for _, m := range customCodecMethods {
+		if _, ok := methods[m]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// structFields returns the fields of the struct type, resolved in the same way as the reflective path.
+//
+// Like encoding/json, anonymous struct fields that have no name in their tags and fields with the inline option
+// are flattened into the parent. Name conflicts are resolved by Go's field-shadowing rules:
+// the shallowest field wins, then the tagged one, and otherwise all of the conflicting fields are dropped.
+func (g *generator) structFields(name string) ([]field, error) {
+	type candidate struct {
+		field
+		depth int
+	}
+	type level struct {
+		st       *ast.StructType
+		index    []int
+		selector string
+	}
+	var candidates []candidate
+	visited := map[*ast.StructType]struct{}{}
+	current := []level{{st: g.pkg.structs[name]}}
+	for depth := 0; len(current) > 0; depth++ {
+		var next []level
+		for _, l := range current {
+			visited[l.st] = struct{}{}
+		}
+		for _, l := range current {
+			i := -1
+			for _, af := range l.st.Fields.List {
+				var tag reflect.StructTag
+				if af.Tag != nil {
+					s, err := strconv.Unquote(af.Tag.Value)
+					if err != nil {
+						return nil, err
+					}
+					tag = reflect.StructTag(s)
+				}
+				names := make([]string, 0, len(af.Names))
+				for _, n := range af.Names {
+					names = append(names, n.Name)
+				}
+				anonymous := len(names) == 0
+				if anonymous {
+					names = append(names, typeName(af.Type))
+				}
+				for _, n := range names {
+					i++
+					exported := ast.IsExported(n)
+					st, local := g.structTypeOf(af.Type)
+					if !exported && !(anonymous && local) {
+						continue
+					}
+					t := structtag.Parse(n, tag, g.config.tagKeys, g.config.attributeName)
+					if t.Ignore {
+						continue
+					}
+					index := append(append(make([]int, 0, len(l.index)+1), l.index...), i)
+					selector := n
+					if l.selector != "" {
+						selector = l.selector + "." + n
+					}
+					if _, ok := af.Type.(*ast.StarExpr); ok && !exported {
+						// cannot allocate the unexported embedded pointer.
+						continue
+					}
+					if t.Inline || (anonymous && !t.Tagged) {
+						flatten, err := g.flattens(af.Type, st, local)
+						if err != nil {
+							return nil, fmt.Errorf("%s.%s: %w", name, selector, err)
+						}
+						if flatten {
+							if _, ok := visited[st]; !ok {
+								next = append(next, level{st: st, index: index, selector: selector})
+							}
+							continue
+						}
+					}
+					if !exported {
+						continue
+					}
+					candidates = append(candidates, candidate{field{
+						index:    index,
+						tag:      t,
+						selector: selector,
+						goField:  name + "." + n,
+						kind:     predeclaredKind(af.Type),
+					}, depth})
+				}
+			}
+		}
+		current = next
+	}
+
+	byName := make(map[string][]candidate, len(candidates))
+	for _, c := range candidates {
+		byName[c.tag.Name] = append(byName[c.tag.Name], c)
+	}
+	fields := make([]field, 0, len(byName))
+	for _, cs := range byName {
+		shallowest := cs[0].depth
+		for _, c := range cs[1:] {
+			shallowest = min(shallowest, c.depth)
+		}
+		var dominant []candidate
+		for _, c := range cs {
+			if c.depth == shallowest {
+				dominant = append(dominant, c)
+			}
+		}
+		if len(dominant) > 1 {
+			var tagged []candidate
+			for _, c := range dominant {
+				if c.tag.Tagged {
+					tagged = append(tagged, c)
+				}
+			}
+			dominant = tagged
+		}
+		if len(dominant) == 1 {
+			fields = append(fields, dominant[0].field)
+		}
+	}
+	slices.SortFunc(fields, func(a, b field) int {
+		return slices.Compare(a.index, b.index)
+	})
+	return fields, nil
+}
+
+// structTypeOf returns the struct type of the type expression if it is declared in the package, or a struct literal.
+func (g *generator) structTypeOf(expr ast.Expr) (*ast.StructType, bool) {
+	switch expr := expr.(type) {
+	case *ast.StructType:
+		return expr, true
+	case *ast.Ident:
+		st, ok := g.pkg.structs[expr.Name]
+		return st, ok
+	case *ast.StarExpr:
+		return g.structTypeOf(expr.X)
+	}
+	return nil, false
+}
+
+// flattens reports whether the embedded or inline field is flattened into the parent.
+//
+// The reflective path resolves types from other packages at run time, which the generator cannot,
+// so the fields that may be flattened are limited to the struct types declared in the package.
+func (g *generator) flattens(expr ast.Expr, st *ast.StructType, local bool) (bool, error) {
+	star, pointer := expr.(*ast.StarExpr)
+	if pointer {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok && g.hasCustomCodec(ident.Name) {
+		return false, nil
+	}
+	switch {
+	case local && pointer:
+		return false, errors.Join(ErrUnsupportedType, errors.New("embedded pointer to a struct cannot be flattened"))
+	case local:
+		return st != nil, nil
+	}
+	if _, ok := expr.(*ast.Ident); ok {
+		// not a struct
+		return false, nil
+	}
+	return false, errors.Join(ErrUnsupportedType,
+		errors.New("type from another package cannot be flattened; specify the attribute name in the tag"))
+}
+
+// predeclaredKind returns the name of the predeclared type that the generated code converts without reflection.
+func predeclaredKind(expr ast.Expr) string {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	switch ident.Name {
+	case "string", "bool",
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"float32", "float64":
+		return ident.Name
+	}
+	return ""
+}
+
+// generator generates the Scan, Value and GormDataType methods.
+type generator struct {
+	config config
+	pkg    *pkg
+	buf    bytes.Buffer
+	// strconv reports whether the generated code uses the strconv package.
+	strconv bool
+}
+
+// generate returns the formatted source of the methods for the struct types in the directory.
+func generate(dir string, c config) ([]byte, error) {
+	p, err := parsePackage(dir, c)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{config: c, pkg: p}
+	var body bytes.Buffer
+	for _, name := range c.types {
+		if _, ok := p.structs[name]; !ok {
+			return nil, errors.Join(ErrTypeNotFound, fmt.Errorf("struct type %s in %s", name, dir))
+		}
+		fields, err := g.structFields(name)
+		if err != nil {
+			return nil, err
+		}
+		g.buf.Reset()
+		g.generateScan(name, fields)
+		g.generateValue(name, fields)
+		g.generateGormDataType(name)
+		body.Write(g.buf.Bytes())
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by sqldav-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", p.name)
+	src.WriteString("import (\n")
+	src.WriteString("\t\"database/sql/driver\"\n")
+	src.WriteString("\t\"github.com/aws/aws-sdk-go-v2/service/dynamodb/types\"\n")
+	src.WriteString("\t\"github.com/miyamo2/sqldav/genruntime\"\n")
+	if g.strconv {
+		src.WriteString("\t\"strconv\"\n")
+	}
+	src.WriteString(")\n")
+	src.Write(body.Bytes())
+	return format.Source(src.Bytes())
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// generateScan generates the Scan method that decodes the map in the same way as the reflective path.
+func (g *generator) generateScan(name string, fields []field) {
+	qualified := strconv.Quote(g.pkg.name + "." + name)
+	g.printf("\n// Scan implements the [sql.Scanner#Scan]\n//\n// [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner\n")
+	g.printf("func (v *%s) Scan(value interface{}) error {\n", name)
+	g.printf("m, err := genruntime.AttributesOf(value)\nif err != nil {\nreturn err\n}\n")
+	g.printf("var r %s\n", name)
+	for _, f := range fields {
+		attr := strconv.Quote(f.tag.Name)
+		goField := strconv.Quote(f.goField)
+		g.printf("if a, ok := m[%s]; ok {\n", attr)
+		g.printf("if err := genruntime.%s(&r.%s, a); err != nil {\n", decodeFunc(f.kind), f.selector)
+		g.printf("return genruntime.DecodeFieldError(err, %s, %s)\n}\n", attr, goField)
+		if f.tag.Required {
+			g.printf("} else if m != nil {\n")
+			g.printf("return genruntime.MissingAttributeError(&r.%s, %s, %s, %s)\n", f.selector, qualified, attr, goField)
+		}
+		g.printf("}\n")
+	}
+	g.printf("if genruntime.DisallowsUnknownAttributes() {\nfor name, a := range m {\nswitch name {\n")
+	if len(fields) > 0 {
+		names := make([]string, 0, len(fields))
+		for _, f := range fields {
+			names = append(names, strconv.Quote(f.tag.Name))
+		}
+		g.printf("case %s:\n", strings.Join(names, ", "))
+	}
+	g.printf("default:\nreturn genruntime.UnknownAttributeError(v, %s, name, a)\n}\n}\n}\n", qualified)
+	g.printf("*v = r\nreturn nil\n}\n")
+}
+
+// decodeFunc returns the name of the sqldav function that decodes the field of the kind.
+func decodeFunc(kind string) string {
+	switch kind {
+	case "string":
+		return "DecodeString"
+	case "bool":
+		return "DecodeBool"
+	case "int", "int8", "int16", "int32", "int64":
+		return "DecodeInt"
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
+		return "DecodeUint"
+	case "float32", "float64":
+		return "DecodeFloat"
+	}
+	return "DecodeField"
+}
+
+// generateValue generates the Value method that encodes the struct in the same way as the reflective path.
+func (g *generator) generateValue(name string, fields []field) {
+	g.printf("\n// Value implements the [driver.Valuer] interface.\n//\n// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer\n")
+	g.printf("func (v %s) Value() (driver.Value, error) {\n", name)
+	g.printf("avm := make(map[string]types.AttributeValue, %d)\n", len(fields))
+	for _, f := range fields {
+		attr := strconv.Quote(f.tag.Name)
+		if f.tag.HasEncodeOptions() {
+			f.kind = ""
+		}
+		switch f.kind {
+		case "string":
+			g.printf("avm[%s] = &types.AttributeValueMemberS{Value: v.%s}\n", attr, f.selector)
+		case "bool":
+			g.printf("avm[%s] = &types.AttributeValueMemberBOOL{Value: v.%s}\n", attr, f.selector)
+		case "int", "int8", "int16", "int32", "int64":
+			g.strconv = true
+			g.printf("avm[%s] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(v.%s), 10)}\n", attr, f.selector)
+		case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
+			g.strconv = true
+			g.printf("avm[%s] = &types.AttributeValueMemberN{Value: strconv.FormatUint(uint64(v.%s), 10)}\n", attr, f.selector)
+		case "float32", "float64":
+			g.strconv = true
+			g.printf("avm[%s] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(float64(v.%s), 'f', -1, %s)}\n",
+				attr, f.selector, strings.TrimPrefix(f.kind, "float"))
+		default:
+			g.printf("if av, err := genruntime.EncodeField(&v.%s, %s); err != nil {\n", f.selector, strconv.Quote(encodeOptions(f.tag)))
+			g.printf("return nil, genruntime.EncodeFieldError(err, %s, %s)\n", attr, strconv.Quote(f.goField))
+			g.printf("} else if av != nil {\navm[%s] = av\n}\n", attr)
+		}
+	}
+	g.printf("return &types.AttributeValueMemberM{Value: avm}, nil\n}\n")
+}
+
+// encodeOptions returns the comma-separated tag options that change the encoding of the value.
+func encodeOptions(t structtag.Tag) string {
+	var opts []string
+	for _, o := range []struct {
+		name string
+		ok   bool
+	}{
+		{"omitempty", t.OmitEmpty},
+		{"omitemptyelem", t.OmitEmptyElem},
+		{"nullempty", t.NullEmpty},
+		{"nullemptyelem", t.NullEmptyElem},
+		{"stringset", t.AsStringSet},
+		{"numberset", t.AsNumberSet},
+		{"binaryset", t.AsBinarySet},
+	} {
+		if o.ok {
+			opts = append(opts, o.name)
+		}
+	}
+	return strings.Join(opts, ",")
+}
+
+// generateGormDataType generates the GormDataType method.
+func (g *generator) generateGormDataType(name string) {
+	g.printf("\n// GormDataType returns the data type for Gorm.\n")
+	g.printf("func (v *%s) GormDataType() string {\nreturn \"M\"\n}\n", name)
+}
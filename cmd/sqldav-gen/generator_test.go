@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/miyamo2/sqldav"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden file")
+
+func defaultConfig(types ...string) config {
+	return config{
+		types:         types,
+		tagKeys:       []string{"gorm", "db", "xorm", "dynamodbav", "json"},
+		attributeName: sqldav.SnakeCase.AttributeName,
+		output:        "user_sqldav.go",
+	}
+}
+
+// TestGenerate_Golden checks that the generated file of the fixture package is up to date.
+// The fixture package checks the parity of the generated methods with the reflective path.
+func TestGenerate_Golden(t *testing.T) {
+	dir := filepath.Join("internal", "fixture")
+	golden := filepath.Join(dir, "user_sqldav.go")
+	got, err := generate(dir, defaultConfig("User", "Address"))
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("generate() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerate_Error(t *testing.T) {
+	type test struct {
+		src   string
+		types []string
+		want  error
+	}
+	tests := map[string]test{
+		"type-not-found": {
+			src:   "package p\n\ntype User struct{}\n",
+			types: []string{"Account"},
+			want:  ErrTypeNotFound,
+		},
+		"embedded-type-from-another-package": {
+			src:   "package p\n\nimport \"time\"\n\ntype User struct {\n\ttime.Time\n}\n",
+			types: []string{"User"},
+			want:  ErrUnsupportedType,
+		},
+		"embedded-pointer": {
+			src:   "package p\n\ntype Audit struct{}\n\ntype User struct {\n\t*Audit\n}\n",
+			types: []string{"User"},
+			want:  ErrUnsupportedType,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "user.go"), []byte(tt.src), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := generate(dir, defaultConfig(tt.types...))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("generate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerate_NamedEmbedded(t *testing.T) {
+	dir := t.TempDir()
+	src := "package p\n\nimport \"time\"\n\ntype User struct {\n\ttime.Time `dynamodbav:\"time\"`\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "user.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate(dir, defaultConfig("User"))
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	if !strings.Contains(string(got), `genruntime.EncodeField(&v.Time, "")`) {
+		t.Errorf("generate() = %s, want the embedded field named by the tag", got)
+	}
+}
+
+func TestGenerate_EmbeddedMarshaler(t *testing.T) {
+	dir := t.TempDir()
+	src := "package p\n\nimport \"github.com/aws/aws-sdk-go-v2/service/dynamodb/types\"\n\n" +
+		"type Audit struct{}\n\n" +
+		"func (a Audit) MarshalAttributeValue() (types.AttributeValue, error) { return nil, nil }\n\n" +
+		"type User struct {\n\tAudit\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "user.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate(dir, defaultConfig("User"))
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	if !strings.Contains(string(got), `genruntime.EncodeField(&v.Audit, "")`) {
+		t.Errorf("generate() = %s, want the embedded marshaler not to be flattened", got)
+	}
+}
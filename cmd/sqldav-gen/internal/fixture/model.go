@@ -0,0 +1,55 @@
+// Package fixture is the input of the golden test of sqldav-gen, and checks the parity of the generated code
+// with the reflective path.
+package fixture
+
+import (
+	"github.com/miyamo2/sqldav"
+)
+
+//go:generate go run ../.. -type=User,Address
+
+// Role is a named string type, which is converted by the reflective path.
+type Role string
+
+// Audit is embedded in User and flattened into the map.
+type Audit struct {
+	CreatedBy string
+	UpdatedAt int64 `dynamodbav:"updated_at"`
+}
+
+// Address is nested in User, and converts itself with the generated methods.
+type Address struct {
+	Zip  string `gorm:"column:zip_code"`
+	City string `xorm:"varchar(25) 'city_name'"`
+}
+
+// Item is nested in User, and is converted by the reflective path.
+type Item struct {
+	Name string
+	Qty  int
+}
+
+type User struct {
+	Audit
+	ID      string  `dynamodbav:"id,required"`
+	Name    string  `json:"name"`
+	Age     int     `db:"age"`
+	Level   int8    `dynamodbav:"level"`
+	Count   uint16  `dynamodbav:"count"`
+	Score   float64 `dynamodbav:"score"`
+	Ratio   float32 `dynamodbav:"ratio"`
+	Active  bool    `dynamodbav:"active"`
+	Nick    string  `dynamodbav:"nick,omitempty"`
+	Role    Role    `dynamodbav:"role"`
+	Note    *string `dynamodbav:"note"`
+	Data    []byte  `dynamodbav:"data"`
+	Tags    sqldav.Set[string]
+	Labels  []string `dynamodbav:"labels,stringset,omitempty"`
+	Items   sqldav.TypedList[Item]
+	Attrs   map[string]int `dynamodbav:"attrs"`
+	Home    Address        `dynamodbav:"home"`
+	Work    *Address       `dynamodbav:"work"`
+	Extra   interface{}    `dynamodbav:"extra"`
+	Secret  string         `dynamodbav:"-"`
+	private string
+}
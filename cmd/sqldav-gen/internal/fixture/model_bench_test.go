@@ -0,0 +1,53 @@
+package fixture
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/miyamo2/sqldav"
+	"testing"
+)
+
+func BenchmarkUser_Value(b *testing.B) {
+	u := users()["full"]
+	b.Run("generated", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := u.Value(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("reflective", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := sqldav.Marshal(reflectiveUser(u)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkUser_Scan(b *testing.B) {
+	av, err := sqldav.Marshal(reflectiveUser(users()["full"]))
+	if err != nil {
+		b.Fatal(err)
+	}
+	value := av.(*types.AttributeValueMemberM)
+	b.Run("generated", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var u User
+			if err := u.Scan(value); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("reflective", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var u reflectiveUser
+			if err := sqldav.Unmarshal(value, &u); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
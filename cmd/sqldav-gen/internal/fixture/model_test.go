@@ -0,0 +1,279 @@
+package fixture
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/miyamo2/sqldav"
+	"math"
+	"testing"
+)
+
+// reflectiveUser has the same fields and tags as User, but no methods, so it is converted by the reflective path.
+type reflectiveUser User
+
+func users() map[string]User {
+	note := "note"
+	return map[string]User{
+		"minimal": {ID: "u1", Tags: sqldav.Set[string]{"a"}},
+		"partial": {ID: "u1", Data: []byte{}, Tags: sqldav.Set[string]{"a"}, Attrs: map[string]int{}},
+		"full": {
+			Audit:  Audit{CreatedBy: "admin", UpdatedAt: 1700000000},
+			ID:     "u1",
+			Name:   "Taro",
+			Age:    -20,
+			Level:  math.MaxInt8,
+			Count:  math.MaxUint16,
+			Score:  0.1,
+			Ratio:  1.5,
+			Active: true,
+			Nick:   "taro",
+			Role:   "admin",
+			Note:   &note,
+			Data:   []byte("data"),
+			Tags:   sqldav.Set[string]{"b", "a"},
+			Labels: []string{"x", "y"},
+			Items:  sqldav.TypedList[Item]{{Name: "apple", Qty: 2}},
+			Attrs:  map[string]int{"k": 1},
+			Home:   Address{Zip: "100-0001", City: "Tokyo"},
+			Work:   &Address{Zip: "530-0001", City: "Osaka"},
+			Extra:  []interface{}{"a", 1.5},
+			Secret: "ignored",
+		},
+	}
+}
+
+func TestUser_Value_Parity(t *testing.T) {
+	for name, u := range users() {
+		t.Run(name, func(t *testing.T) {
+			want, err := sqldav.Marshal(reflectiveUser(u))
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			got, err := u.Value()
+			if err != nil {
+				t.Fatalf("Value() error = %v", err)
+			}
+			if diff := cmp.Diff(string(wire(t, want)), string(wire(t, got.(types.AttributeValue)))); diff != "" {
+				t.Errorf("Value() mismatch (-reflective +generated):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUser_Value_Error(t *testing.T) {
+	u := User{ID: "u1"}
+	_, reflective := sqldav.Marshal(reflectiveUser(u))
+	_, generated := u.Value()
+	for _, err := range []error{reflective, generated} {
+		if !errors.Is(err, sqldav.ErrSetIsEmpty) {
+			t.Errorf("error = %v, want %v", err, sqldav.ErrSetIsEmpty)
+		}
+		var ee *sqldav.EncodeError
+		if !errors.As(err, &ee) || ee.Path != "tags" {
+			t.Errorf("error = %v, want path %v", err, "tags")
+		}
+	}
+}
+
+func TestUser_Scan_Parity(t *testing.T) {
+	for name, u := range users() {
+		u.Secret = ""
+		t.Run(name, func(t *testing.T) {
+			av, err := sqldav.Marshal(reflectiveUser(u))
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var want reflectiveUser
+			wantErr := sqldav.Unmarshal(av, &want)
+			var got User
+			err = got.Scan(av)
+			if diff := cmp.Diff(decodeErrorPath(wantErr), decodeErrorPath(err)); diff != "" {
+				t.Fatalf("Scan() error = %v, want %v", err, wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(User(want), got, cmp.AllowUnexported(User{})); diff != "" {
+				t.Errorf("Scan() mismatch (-reflective +generated):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUser_Scan_Driver(t *testing.T) {
+	var got User
+	err := got.Scan(map[string]interface{}{
+		"id":        "u1",
+		"age":       float64(20),
+		"ratio":     float64(0.5),
+		"active":    true,
+		"home":      map[string]interface{}{"zip_code": "100-0001"},
+		"tags":      []string{"a"},
+		"secret":    "ignored",
+		"undefined": "ignored",
+	})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	want := User{ID: "u1", Age: 20, Ratio: 0.5, Active: true, Home: Address{Zip: "100-0001"}, Tags: sqldav.Set[string]{"a"}}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(User{})); diff != "" {
+		t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUser_Scan_DriverAdapter(t *testing.T) {
+	sqldav.SetDefaultDecoderOptions(sqldav.WithDriverAdapter(sqldav.DetectDriverAdapter))
+	t.Cleanup(func() {
+		sqldav.SetDefaultDecoderOptions()
+	})
+	var got User
+	err := got.Scan(map[string]interface{}{
+		"id":    "u1",
+		"name":  `{"S": "hi"}`,
+		"role":  `{"S": "hi"}`,
+		"note":  `{"N": "1"}`,
+		"extra": `{"BOOL": true}`,
+	})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	note := `{"N": "1"}`
+	want := User{ID: "u1", Name: `{"S": "hi"}`, Role: `{"S": "hi"}`, Note: &note, Extra: `{"BOOL": true}`}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(User{})); diff != "" {
+		t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUser_Scan_Error(t *testing.T) {
+	type want struct {
+		err  error
+		path string
+	}
+	type test struct {
+		args map[string]types.AttributeValue
+		want want
+	}
+	tests := map[string]test{
+		"missing-required": {
+			args: map[string]types.AttributeValue{},
+			want: want{err: sqldav.ErrMissingAttribute, path: "id"},
+		},
+		"overflow": {
+			args: map[string]types.AttributeValue{
+				"id":    &types.AttributeValueMemberS{Value: "u1"},
+				"level": &types.AttributeValueMemberN{Value: "128"},
+			},
+			want: want{err: sqldav.ErrNumberOverflow, path: "level"},
+		},
+		"fraction": {
+			args: map[string]types.AttributeValue{
+				"id":  &types.AttributeValueMemberS{Value: "u1"},
+				"age": &types.AttributeValueMemberN{Value: "1.5"},
+			},
+			want: want{err: sqldav.ErrNumberIsNotInteger, path: "age"},
+		},
+		"nested": {
+			args: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: "u1"},
+				"items": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+					&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+						"qty": &types.AttributeValueMemberS{Value: "two"},
+					}},
+				}},
+			},
+			want: want{err: sqldav.ErrNestedStructHasIncompatibleAttributes, path: "items[0].qty"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			av := &types.AttributeValueMemberM{Value: tt.args}
+			var r reflectiveUser
+			reflective := sqldav.Unmarshal(av, &r)
+			var u User
+			generated := u.Scan(av)
+			for _, err := range []error{reflective, generated} {
+				if !errors.Is(err, tt.want.err) {
+					t.Errorf("error = %v, want %v", err, tt.want.err)
+				}
+				var de *sqldav.DecodeError
+				if !errors.As(err, &de) || de.Path != tt.want.path {
+					t.Errorf("error = %v, want path %v", err, tt.want.path)
+				}
+			}
+		})
+	}
+}
+
+func TestUser_Scan_DisallowUnknownAttributes(t *testing.T) {
+	sqldav.SetDefaultDecoderOptions(sqldav.WithDisallowUnknownAttributes())
+	t.Cleanup(func() {
+		sqldav.SetDefaultDecoderOptions()
+	})
+	var u User
+	err := u.Scan(&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"id":        &types.AttributeValueMemberS{Value: "u1"},
+		"undefined": &types.AttributeValueMemberS{Value: "x"},
+	}})
+	if !errors.Is(err, sqldav.ErrUnknownAttribute) {
+		t.Errorf("Scan() error = %v, want %v", err, sqldav.ErrUnknownAttribute)
+	}
+}
+
+// decodeErrorPath returns the path of the *sqldav.DecodeError in the err, or "-" if the err is nil.
+func decodeErrorPath(err error) string {
+	if err == nil {
+		return "-"
+	}
+	var de *sqldav.DecodeError
+	if errors.As(err, &de) {
+		return de.Path
+	}
+	return err.Error()
+}
+
+// wire returns the DynamoDB JSON of the types.AttributeValue, whose map keys are sorted.
+func wire(t *testing.T, av types.AttributeValue) []byte {
+	t.Helper()
+	b, err := json.Marshal(wireValue(av))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	return b
+}
+
+func wireValue(av types.AttributeValue) map[string]interface{} {
+	switch av := av.(type) {
+	case *types.AttributeValueMemberS:
+		return map[string]interface{}{"S": av.Value}
+	case *types.AttributeValueMemberN:
+		return map[string]interface{}{"N": av.Value}
+	case *types.AttributeValueMemberB:
+		return map[string]interface{}{"B": base64.StdEncoding.EncodeToString(av.Value)}
+	case *types.AttributeValueMemberBOOL:
+		return map[string]interface{}{"BOOL": av.Value}
+	case *types.AttributeValueMemberNULL:
+		return map[string]interface{}{"NULL": av.Value}
+	case *types.AttributeValueMemberSS:
+		return map[string]interface{}{"SS": av.Value}
+	case *types.AttributeValueMemberNS:
+		return map[string]interface{}{"NS": av.Value}
+	case *types.AttributeValueMemberBS:
+		return map[string]interface{}{"BS": av.Value}
+	case *types.AttributeValueMemberL:
+		l := make([]interface{}, 0, len(av.Value))
+		for _, v := range av.Value {
+			l = append(l, wireValue(v))
+		}
+		return map[string]interface{}{"L": l}
+	case *types.AttributeValueMemberM:
+		m := make(map[string]interface{}, len(av.Value))
+		for k, v := range av.Value {
+			m[k] = wireValue(v)
+		}
+		return map[string]interface{}{"M": m}
+	}
+	return map[string]interface{}{"unknown": av}
+}
@@ -0,0 +1,264 @@
+// Code generated by sqldav-gen. DO NOT EDIT.
+
+package fixture
+
+import (
+	"database/sql/driver"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/miyamo2/sqldav/genruntime"
+	"strconv"
+)
+
+// Scan implements the [sql.Scanner#Scan]
+//
+// [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
+func (v *User) Scan(value interface{}) error {
+	m, err := genruntime.AttributesOf(value)
+	if err != nil {
+		return err
+	}
+	var r User
+	if a, ok := m["created_by"]; ok {
+		if err := genruntime.DecodeString(&r.Audit.CreatedBy, a); err != nil {
+			return genruntime.DecodeFieldError(err, "created_by", "User.CreatedBy")
+		}
+	}
+	if a, ok := m["updated_at"]; ok {
+		if err := genruntime.DecodeInt(&r.Audit.UpdatedAt, a); err != nil {
+			return genruntime.DecodeFieldError(err, "updated_at", "User.UpdatedAt")
+		}
+	}
+	if a, ok := m["id"]; ok {
+		if err := genruntime.DecodeString(&r.ID, a); err != nil {
+			return genruntime.DecodeFieldError(err, "id", "User.ID")
+		}
+	} else if m != nil {
+		return genruntime.MissingAttributeError(&r.ID, "fixture.User", "id", "User.ID")
+	}
+	if a, ok := m["name"]; ok {
+		if err := genruntime.DecodeString(&r.Name, a); err != nil {
+			return genruntime.DecodeFieldError(err, "name", "User.Name")
+		}
+	}
+	if a, ok := m["age"]; ok {
+		if err := genruntime.DecodeInt(&r.Age, a); err != nil {
+			return genruntime.DecodeFieldError(err, "age", "User.Age")
+		}
+	}
+	if a, ok := m["level"]; ok {
+		if err := genruntime.DecodeInt(&r.Level, a); err != nil {
+			return genruntime.DecodeFieldError(err, "level", "User.Level")
+		}
+	}
+	if a, ok := m["count"]; ok {
+		if err := genruntime.DecodeUint(&r.Count, a); err != nil {
+			return genruntime.DecodeFieldError(err, "count", "User.Count")
+		}
+	}
+	if a, ok := m["score"]; ok {
+		if err := genruntime.DecodeFloat(&r.Score, a); err != nil {
+			return genruntime.DecodeFieldError(err, "score", "User.Score")
+		}
+	}
+	if a, ok := m["ratio"]; ok {
+		if err := genruntime.DecodeFloat(&r.Ratio, a); err != nil {
+			return genruntime.DecodeFieldError(err, "ratio", "User.Ratio")
+		}
+	}
+	if a, ok := m["active"]; ok {
+		if err := genruntime.DecodeBool(&r.Active, a); err != nil {
+			return genruntime.DecodeFieldError(err, "active", "User.Active")
+		}
+	}
+	if a, ok := m["nick"]; ok {
+		if err := genruntime.DecodeString(&r.Nick, a); err != nil {
+			return genruntime.DecodeFieldError(err, "nick", "User.Nick")
+		}
+	}
+	if a, ok := m["role"]; ok {
+		if err := genruntime.DecodeField(&r.Role, a); err != nil {
+			return genruntime.DecodeFieldError(err, "role", "User.Role")
+		}
+	}
+	if a, ok := m["note"]; ok {
+		if err := genruntime.DecodeField(&r.Note, a); err != nil {
+			return genruntime.DecodeFieldError(err, "note", "User.Note")
+		}
+	}
+	if a, ok := m["data"]; ok {
+		if err := genruntime.DecodeField(&r.Data, a); err != nil {
+			return genruntime.DecodeFieldError(err, "data", "User.Data")
+		}
+	}
+	if a, ok := m["tags"]; ok {
+		if err := genruntime.DecodeField(&r.Tags, a); err != nil {
+			return genruntime.DecodeFieldError(err, "tags", "User.Tags")
+		}
+	}
+	if a, ok := m["labels"]; ok {
+		if err := genruntime.DecodeField(&r.Labels, a); err != nil {
+			return genruntime.DecodeFieldError(err, "labels", "User.Labels")
+		}
+	}
+	if a, ok := m["items"]; ok {
+		if err := genruntime.DecodeField(&r.Items, a); err != nil {
+			return genruntime.DecodeFieldError(err, "items", "User.Items")
+		}
+	}
+	if a, ok := m["attrs"]; ok {
+		if err := genruntime.DecodeField(&r.Attrs, a); err != nil {
+			return genruntime.DecodeFieldError(err, "attrs", "User.Attrs")
+		}
+	}
+	if a, ok := m["home"]; ok {
+		if err := genruntime.DecodeField(&r.Home, a); err != nil {
+			return genruntime.DecodeFieldError(err, "home", "User.Home")
+		}
+	}
+	if a, ok := m["work"]; ok {
+		if err := genruntime.DecodeField(&r.Work, a); err != nil {
+			return genruntime.DecodeFieldError(err, "work", "User.Work")
+		}
+	}
+	if a, ok := m["extra"]; ok {
+		if err := genruntime.DecodeField(&r.Extra, a); err != nil {
+			return genruntime.DecodeFieldError(err, "extra", "User.Extra")
+		}
+	}
+	if genruntime.DisallowsUnknownAttributes() {
+		for name, a := range m {
+			switch name {
+			case "created_by", "updated_at", "id", "name", "age", "level", "count", "score", "ratio", "active", "nick", "role", "note", "data", "tags", "labels", "items", "attrs", "home", "work", "extra":
+			default:
+				return genruntime.UnknownAttributeError(v, "fixture.User", name, a)
+			}
+		}
+	}
+	*v = r
+	return nil
+}
+
+// Value implements the [driver.Valuer] interface.
+//
+// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
+func (v User) Value() (driver.Value, error) {
+	avm := make(map[string]types.AttributeValue, 21)
+	avm["created_by"] = &types.AttributeValueMemberS{Value: v.Audit.CreatedBy}
+	avm["updated_at"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(v.Audit.UpdatedAt), 10)}
+	avm["id"] = &types.AttributeValueMemberS{Value: v.ID}
+	avm["name"] = &types.AttributeValueMemberS{Value: v.Name}
+	avm["age"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(v.Age), 10)}
+	avm["level"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(v.Level), 10)}
+	avm["count"] = &types.AttributeValueMemberN{Value: strconv.FormatUint(uint64(v.Count), 10)}
+	avm["score"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(float64(v.Score), 'f', -1, 64)}
+	avm["ratio"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(float64(v.Ratio), 'f', -1, 32)}
+	avm["active"] = &types.AttributeValueMemberBOOL{Value: v.Active}
+	if av, err := genruntime.EncodeField(&v.Nick, "omitempty"); err != nil {
+		return nil, genruntime.EncodeFieldError(err, "nick", "User.Nick")
+	} else if av != nil {
+		avm["nick"] = av
+	}
+	if av, err := genruntime.EncodeField(&v.Role, ""); err != nil {
+		return nil, genruntime.EncodeFieldError(err, "role", "User.Role")
+	} else if av != nil {
+		avm["role"] = av
+	}
+	if av, err := genruntime.EncodeField(&v.Note, ""); err != nil {
+		return nil, genruntime.EncodeFieldError(err, "note", "User.Note")
+	} else if av != nil {
+		avm["note"] = av
+	}
+	if av, err := genruntime.EncodeField(&v.Data, ""); err != nil {
+		return nil, genruntime.EncodeFieldError(err, "data", "User.Data")
+	} else if av != nil {
+		avm["data"] = av
+	}
+	if av, err := genruntime.EncodeField(&v.Tags, ""); err != nil {
+		return nil, genruntime.EncodeFieldError(err, "tags", "User.Tags")
+	} else if av != nil {
+		avm["tags"] = av
+	}
+	if av, err := genruntime.EncodeField(&v.Labels, "omitempty,stringset"); err != nil {
+		return nil, genruntime.EncodeFieldError(err, "labels", "User.Labels")
+	} else if av != nil {
+		avm["labels"] = av
+	}
+	if av, err := genruntime.EncodeField(&v.Items, ""); err != nil {
+		return nil, genruntime.EncodeFieldError(err, "items", "User.Items")
+	} else if av != nil {
+		avm["items"] = av
+	}
+	if av, err := genruntime.EncodeField(&v.Attrs, ""); err != nil {
+		return nil, genruntime.EncodeFieldError(err, "attrs", "User.Attrs")
+	} else if av != nil {
+		avm["attrs"] = av
+	}
+	if av, err := genruntime.EncodeField(&v.Home, ""); err != nil {
+		return nil, genruntime.EncodeFieldError(err, "home", "User.Home")
+	} else if av != nil {
+		avm["home"] = av
+	}
+	if av, err := genruntime.EncodeField(&v.Work, ""); err != nil {
+		return nil, genruntime.EncodeFieldError(err, "work", "User.Work")
+	} else if av != nil {
+		avm["work"] = av
+	}
+	if av, err := genruntime.EncodeField(&v.Extra, ""); err != nil {
+		return nil, genruntime.EncodeFieldError(err, "extra", "User.Extra")
+	} else if av != nil {
+		avm["extra"] = av
+	}
+	return &types.AttributeValueMemberM{Value: avm}, nil
+}
+
+// GormDataType returns the data type for Gorm.
+func (v *User) GormDataType() string {
+	return "M"
+}
+
+// Scan implements the [sql.Scanner#Scan]
+//
+// [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
+func (v *Address) Scan(value interface{}) error {
+	m, err := genruntime.AttributesOf(value)
+	if err != nil {
+		return err
+	}
+	var r Address
+	if a, ok := m["zip_code"]; ok {
+		if err := genruntime.DecodeString(&r.Zip, a); err != nil {
+			return genruntime.DecodeFieldError(err, "zip_code", "Address.Zip")
+		}
+	}
+	if a, ok := m["city_name"]; ok {
+		if err := genruntime.DecodeString(&r.City, a); err != nil {
+			return genruntime.DecodeFieldError(err, "city_name", "Address.City")
+		}
+	}
+	if genruntime.DisallowsUnknownAttributes() {
+		for name, a := range m {
+			switch name {
+			case "zip_code", "city_name":
+			default:
+				return genruntime.UnknownAttributeError(v, "fixture.Address", name, a)
+			}
+		}
+	}
+	*v = r
+	return nil
+}
+
+// Value implements the [driver.Valuer] interface.
+//
+// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
+func (v Address) Value() (driver.Value, error) {
+	avm := make(map[string]types.AttributeValue, 2)
+	avm["zip_code"] = &types.AttributeValueMemberS{Value: v.Zip}
+	avm["city_name"] = &types.AttributeValueMemberS{Value: v.City}
+	return &types.AttributeValueMemberM{Value: avm}, nil
+}
+
+// GormDataType returns the data type for Gorm.
+func (v *Address) GormDataType() string {
+	return "M"
+}
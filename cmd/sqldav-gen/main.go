@@ -0,0 +1,88 @@
+// Sqldav-gen generates the Scan, Value and GormDataType methods of struct types without reflection.
+//
+// The generated methods convert the struct to and from a DynamoDB map in the same way as sqldav.Document,
+// using the default tag keys and naming strategy unless specified by the flags.
+// The fields of the predeclared types such as string and int are converted without reflection,
+// and the other fields fall back to the default sqldav.Encoder and sqldav.Decoder.
+//
+// Usage:
+//
+//	//go:generate go run github.com/miyamo2/sqldav/cmd/sqldav-gen -type=User,Address
+//
+// Flags:
+//
+//	-type    comma-separated list of struct type names; required
+//	-output  output file name; default <first type in snake case>_sqldav.go
+//	-tags    comma-separated list of struct tag keys in order of precedence; default gorm,db,xorm,dynamodbav,json
+//	-naming  naming strategy of the fields that have no tag: snake, camel, pascal, kebab or identity; default snake
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/miyamo2/sqldav"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of struct type names; required")
+	output    = flag.String("output", "", "output file name; default <first type in snake case>_sqldav.go")
+	tagKeys   = flag.String("tags", "gorm,db,xorm,dynamodbav,json", "comma-separated list of struct tag keys in order of precedence")
+	naming    = flag.String("naming", "snake", "naming strategy of the fields that have no tag: snake, camel, pascal, kebab or identity")
+)
+
+// namingStrategies are the naming strategies selected by the -naming flag.
+var namingStrategies = map[string]sqldav.NamingStrategy{
+	"snake":    sqldav.SnakeCase,
+	"camel":    sqldav.CamelCase,
+	"pascal":   sqldav.PascalCase,
+	"kebab":    sqldav.KebabCase,
+	"identity": sqldav.Identity,
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of sqldav-gen:\n")
+	fmt.Fprintf(os.Stderr, "\tsqldav-gen [flags] -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	strategy, ok := namingStrategies[*naming]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "sqldav-gen: unknown naming strategy %q\n", *naming)
+		os.Exit(2)
+	}
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+	types := strings.Split(*typeNames, ",")
+	name := *output
+	if name == "" {
+		name = sqldav.SnakeCase.AttributeName(types[0]) + "_sqldav.go"
+	}
+
+	src, err := generate(dir, config{
+		types:         types,
+		tagKeys:       strings.Split(*tagKeys, ","),
+		attributeName: strategy.AttributeName,
+		output:        name,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sqldav-gen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "sqldav-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,291 @@
+package sqldav
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/miyamo2/sqldav/internal/structtag"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Option configures both the Encoder and the Decoder.
+type Option interface {
+	EncoderOption
+	DecoderOption
+}
+
+// fieldOption is an Option that configures the resolution of the attribute name.
+type fieldOption func(c *fieldConfig)
+
+func (f fieldOption) applyToEncoder(e *Encoder) {
+	f(&e.fieldConfig)
+}
+
+func (f fieldOption) applyToDecoder(d *Decoder) {
+	f(&d.fieldConfig)
+}
+
+// WithTagKeys sets the struct tag keys that specify the attribute name, in order of precedence.
+//
+// The default is set by SetDefaultTagKeys.
+// The attribute name is read from `column:` for the gorm tag, from the single-quoted string for the xorm tag,
+// and from the part before the first comma for any other tag such as dynamodbav and json.
+//
+// The options such as omitempty, and "-", are read from the first tag other than gorm and xorm that specifies them,
+// as long as it is the tag that specifies the name, ranks higher than it, or specifies the same name.
+// e.g. `db:"nick" dynamodbav:"nick,omitempty"` has the omitempty option, but `db:"name" json:"nick,omitempty"` does not.
+func WithTagKeys(keys ...string) Option {
+	keys = append([]string{}, keys...)
+	return fieldOption(func(c *fieldConfig) {
+		c.tagKeys = keys
+	})
+}
+
+// WithNamingStrategy sets the NamingStrategy used for the struct fields that have no tag for the attribute name.
+//
+// The default is set by SetDefaultNamingStrategy.
+func WithNamingStrategy(s NamingStrategy) Option {
+	return fieldOption(func(c *fieldConfig) {
+		c.namingStrategy = s
+	})
+}
+
+// defaultTagKeys are the default struct tag keys that specify the attribute name.
+var defaultTagKeys = []string{"gorm", "db", "xorm", "dynamodbav", "json"}
+
+// globalFieldConfig is the fieldConfig used by the Encoder and the Decoder that have no field options.
+var globalFieldConfig atomic.Pointer[fieldConfig]
+
+func init() {
+	globalFieldConfig.Store(&fieldConfig{
+		tagKeys:        defaultTagKeys,
+		namingStrategy: SnakeCase,
+	})
+}
+
+// SetDefaultTagKeys sets the struct tag keys used by every Encoder and Decoder that have no WithTagKeys option,
+// including the ones used by Marshal, Unmarshal and the sqldav types.
+//
+// The default is "gorm", "db", "xorm", "dynamodbav" and "json".
+func SetDefaultTagKeys(keys ...string) {
+	c := *globalFieldConfig.Load()
+	c.tagKeys = append([]string{}, keys...)
+	globalFieldConfig.Store(&c)
+}
+
+// SetDefaultNamingStrategy sets the NamingStrategy used by every Encoder and Decoder that have no WithNamingStrategy option,
+// including the ones used by Marshal, Unmarshal and the sqldav types.
+//
+// The default is SnakeCase. nil restores the default.
+func SetDefaultNamingStrategy(s NamingStrategy) {
+	if s == nil {
+		s = SnakeCase
+	}
+	c := *globalFieldConfig.Load()
+	c.namingStrategy = s
+	globalFieldConfig.Store(&c)
+}
+
+// fieldConfig is the configuration to resolve the attribute name from the struct field,
+// and the converters that decide which struct fields are converted as a whole.
+//
+// The zero value follows the global defaults and caches nothing.
+type fieldConfig struct {
+	tagKeys        []string
+	namingStrategy NamingStrategy
+	converters     converters
+	// structs caches the structFields per structFieldsKey.
+	structs *sync.Map
+}
+
+// newFieldConfig returns the fieldConfig that follows the global defaults.
+func newFieldConfig() fieldConfig {
+	return fieldConfig{structs: &sync.Map{}}
+}
+
+// resolve returns the fieldConfig whose unset fields are filled with the global defaults.
+func (c *fieldConfig) resolve(global *fieldConfig) *fieldConfig {
+	if c.tagKeys != nil && c.namingStrategy != nil {
+		return c
+	}
+	r := *c
+	if r.tagKeys == nil {
+		r.tagKeys = global.tagKeys
+	}
+	if r.namingStrategy == nil {
+		r.namingStrategy = global.namingStrategy
+	}
+	return &r
+}
+
+// fieldTagOf returns the structtag.Tag of the struct field.
+//
+// The name is read from the first tag that specifies it, in order of precedence.
+// The options and "-" are read from the first comma-separated tag, such as dynamodbav and json, that specifies them,
+// among the tags up to the one that specifies the name and the lower ones that specify the same name.
+func (c *fieldConfig) fieldTagOf(sf reflect.StructField) structtag.Tag {
+	r := c.resolve(globalFieldConfig.Load())
+	return structtag.Parse(sf.Name, sf.Tag, r.tagKeys, r.namingStrategy.AttributeName)
+}
+
+// structField is the struct field converted to an attribute, including the ones promoted from embedded structs.
+type structField struct {
+	index []int
+	tag   structtag.Tag
+	// typ is the Go type of the field.
+	typ reflect.Type
+	// field is the name of the field qualified by the struct type. e.g. `Address.Zip`
+	field string
+}
+
+// structFieldsKey is the key of the cached structFields.
+// The global defaults are part of the key, so that SetDefaultTagKeys, SetDefaultNamingStrategy and RegisterConverter
+// invalidate the cache.
+type structFieldsKey struct {
+	rt         reflect.Type
+	global     *fieldConfig
+	converters *converters
+}
+
+// structFieldsOf returns the fields of the struct type. They are computed once per type and cached.
+func (c *fieldConfig) structFieldsOf(rt reflect.Type) []structField {
+	global := globalFieldConfig.Load()
+	if c.structs == nil {
+		return c.resolve(global).compileStructFields(rt)
+	}
+	key := structFieldsKey{rt: rt, global: global, converters: globalConverters.Load()}
+	if fields, ok := c.structs.Load(key); ok {
+		return fields.([]structField)
+	}
+	fields, _ := c.structs.LoadOrStore(key, c.resolve(global).compileStructFields(rt))
+	return fields.([]structField)
+}
+
+// compileStructFields returns the fields of the struct type.
+//
+// Like encoding/json, anonymous struct fields that have no name in their tags and fields with the inline option
+// are flattened into the parent. Name conflicts are resolved by Go's field-shadowing rules:
+// the shallowest field wins, then the tagged one, and otherwise all of the conflicting fields are dropped.
+func (c *fieldConfig) compileStructFields(rt reflect.Type) []structField {
+	type candidate struct {
+		structField
+		depth int
+	}
+	type level struct {
+		rt    reflect.Type
+		index []int
+	}
+	var candidates []candidate
+	visited := map[reflect.Type]struct{}{}
+	current := []level{{rt: rt}}
+	for depth := 0; len(current) > 0; depth++ {
+		var next []level
+		for _, l := range current {
+			visited[l.rt] = struct{}{}
+		}
+		for _, l := range current {
+			for i := 0; i < l.rt.NumField(); i++ {
+				sf := l.rt.Field(i)
+				ft := sf.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if !sf.IsExported() && !(sf.Anonymous && ft.Kind() == reflect.Struct) {
+					continue
+				}
+				tag := c.fieldTagOf(sf)
+				if tag.Ignore {
+					continue
+				}
+				index := append(append(make([]int, 0, len(l.index)+1), l.index...), i)
+				if ft.Kind() == reflect.Struct && (tag.Inline || (sf.Anonymous && !tag.Tagged)) && !c.hasCustomCodec(ft) {
+					if !sf.IsExported() && sf.Type.Kind() == reflect.Pointer {
+						// cannot allocate the unexported embedded pointer.
+						continue
+					}
+					if _, ok := visited[ft]; !ok {
+						next = append(next, level{rt: ft, index: index})
+					}
+					continue
+				}
+				if !sf.IsExported() {
+					continue
+				}
+				candidates = append(candidates, candidate{structField{
+					index: index,
+					tag:   tag,
+					typ:   sf.Type,
+					field: goFieldName(rt, index),
+				}, depth})
+			}
+		}
+		current = next
+	}
+
+	byName := make(map[string][]candidate, len(candidates))
+	for _, c := range candidates {
+		byName[c.tag.Name] = append(byName[c.tag.Name], c)
+	}
+	fields := make([]structField, 0, len(byName))
+	for _, cs := range byName {
+		shallowest := cs[0].depth
+		for _, c := range cs[1:] {
+			shallowest = min(shallowest, c.depth)
+		}
+		var dominant []candidate
+		for _, c := range cs {
+			if c.depth == shallowest {
+				dominant = append(dominant, c)
+			}
+		}
+		if len(dominant) > 1 {
+			var tagged []candidate
+			for _, c := range dominant {
+				if c.tag.Tagged {
+					tagged = append(tagged, c)
+				}
+			}
+			dominant = tagged
+		}
+		if len(dominant) == 1 {
+			fields = append(fields, dominant[0].structField)
+		}
+	}
+	slices.SortFunc(fields, func(a, b structField) int {
+		return slices.Compare(a.index, b.index)
+	})
+	return fields
+}
+
+// goFieldName returns the name of the nested field qualified by the struct type. e.g. `Address.Zip`
+func goFieldName(rt reflect.Type, index []int) string {
+	name := rt.FieldByIndex(index).Name
+	if rt.Name() == "" {
+		return name
+	}
+	return rt.Name() + "." + name
+}
+
+var (
+	attributeValueType    = reflect.TypeOf((*types.AttributeValue)(nil)).Elem()
+	scannerType           = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType            = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	documentEncoderType   = reflect.TypeOf((*documentEncoder)(nil)).Elem()
+	documentDecoderType   = reflect.TypeOf((*documentDecoder)(nil)).Elem()
+	emptyInterfaceType    = reflect.TypeOf((*interface{})(nil)).Elem()
+	optionalAttributeType = reflect.TypeOf((*optionalAttribute)(nil)).Elem()
+)
+
+// hasCustomCodec reports whether the struct type converts itself or has a converter, and therefore is not flattened.
+func (c *fieldConfig) hasCustomCodec(rt reflect.Type) bool {
+	if conv := c.converterOf(rt); conv.encode != nil || conv.decode != nil {
+		return true
+	}
+	pt := reflect.PointerTo(rt)
+	return pt.Implements(scannerType) || pt.Implements(valuerType) ||
+		pt.Implements(documentEncoderType) || pt.Implements(documentDecoderType) ||
+		implementsMarshaler(pt) || implementsUnmarshaler(pt)
+}
@@ -0,0 +1,85 @@
+package sqldav
+
+import (
+	"strconv"
+	"testing"
+)
+
+type benchmarkRow struct {
+	UserID    string `gorm:"column:user_id"`
+	Name      string `db:"name"`
+	Email     string `xorm:"varchar(255) 'email'"`
+	Age       int
+	Score     float64
+	Active    bool
+	CreatedAt int64 `dynamodbav:"created_at"`
+	Tags      []string
+	Address   Address
+}
+
+// benchmarkRows returns the driver values of the TypedList[benchmarkRow] with n elements.
+func benchmarkRows(n int) []interface{} {
+	rows := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		rows = append(rows, map[string]interface{}{
+			"user_id":    "user-" + strconv.Itoa(i),
+			"name":       "foo",
+			"email":      "foo@example.com",
+			"age":        float64(20),
+			"score":      0.5,
+			"active":     true,
+			"created_at": float64(1700000000),
+			"tags":       []interface{}{"a", "b"},
+			"address": map[string]interface{}{
+				"zip":    "100-0001",
+				"street": "Chiyoda",
+				"floor":  float64(3),
+			},
+		})
+	}
+	return rows
+}
+
+// BenchmarkTypedList_Scan compares the Decoder returned by NewDecoder, which caches the struct field metadata,
+// with the zero Decoder, which computes it on every call.
+func BenchmarkTypedList_Scan(b *testing.B) {
+	rows := benchmarkRows(1000)
+	benchmarks := map[string]*Decoder{
+		"new-decoder":  NewDecoder(),
+		"zero-decoder": {},
+	}
+	for name, d := range benchmarks {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var l TypedList[benchmarkRow]
+				if err := d.DecodeValue(rows, &l); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkTypedList_Value compares the Encoder returned by NewEncoder, which caches the struct field metadata,
+// with the zero Encoder, which computes it on every call. Both share the encoders compiled per type.
+func BenchmarkTypedList_Value(b *testing.B) {
+	var rows TypedList[benchmarkRow]
+	if err := rows.Scan(benchmarkRows(1000)); err != nil {
+		b.Fatal(err)
+	}
+	benchmarks := map[string]*Encoder{
+		"new-encoder":  NewEncoder(),
+		"zero-encoder": {},
+	}
+	for name, e := range benchmarks {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := e.Encode(rows); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package sqldav
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DecodeError is the error that occurs when a value cannot be decoded into a Go value.
+//
+// It wraps the cause, so errors.Is works with the sentinel errors such as ErrNestedStructHasIncompatibleAttributes.
+type DecodeError struct {
+	// Path is the path to the attribute in the document. e.g. `items[3].address.zip`
+	Path string
+	// Type is the Go type of the destination.
+	Type reflect.Type
+	// Field is the Go struct field of the destination. e.g. `Address.Zip`
+	Field string
+	// Expected is the DynamoDB type expected by the destination. e.g. `S`
+	Expected string
+	// Actual is the DynamoDB type of the value, or the Go type if the value is from the database/sql driver.
+	Actual string
+	// Err is the cause.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	var b strings.Builder
+	b.WriteString("sqldav: cannot decode")
+	if e.Path != "" {
+		fmt.Fprintf(&b, " %s", e.Path)
+	}
+	if e.Actual != "" {
+		fmt.Fprintf(&b, " of %s", e.Actual)
+	}
+	fmt.Fprintf(&b, " into %v", e.Type)
+	if e.Field != "" {
+		fmt.Fprintf(&b, " (%s)", e.Field)
+	}
+	if e.Expected != "" {
+		fmt.Fprintf(&b, ", expected %s", e.Expected)
+	}
+	fmt.Fprintf(&b, ": %v", e.Err)
+	return b.String()
+}
+
+// Unwrap returns the cause.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// EncodeError is the error that occurs when a Go value cannot be encoded into a types.AttributeValue.
+//
+// It wraps the cause, so errors.Is works with the sentinel errors such as ErrSetIsEmpty.
+type EncodeError struct {
+	// Path is the path to the attribute in the document. e.g. `items[3].roles`
+	Path string
+	// Type is the Go type of the value.
+	Type reflect.Type
+	// Field is the Go struct field of the value. e.g. `Item.Roles`
+	Field string
+	// Err is the cause.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *EncodeError) Error() string {
+	var b strings.Builder
+	b.WriteString("sqldav: cannot encode")
+	if e.Path != "" {
+		fmt.Fprintf(&b, " %s", e.Path)
+	}
+	fmt.Fprintf(&b, " from %v", e.Type)
+	if e.Field != "" {
+		fmt.Fprintf(&b, " (%s)", e.Field)
+	}
+	fmt.Fprintf(&b, ": %v", e.Err)
+	return b.String()
+}
+
+// Unwrap returns the cause.
+func (e *EncodeError) Unwrap() error {
+	return e.Err
+}
+
+// newDecodeError returns the *DecodeError for the destination type and the value, unless the err already has one.
+func newDecodeError(rt reflect.Type, value interface{}, err error) error {
+	var de *DecodeError
+	if errors.As(err, &de) {
+		return err
+	}
+	return &DecodeError{
+		Type:     rt,
+		Expected: expectedAttributeType(rt),
+		Actual:   actualAttributeType(value),
+		Err:      err,
+	}
+}
+
+// newEncodeError returns the *EncodeError for the type, unless the err already has one.
+func newEncodeError(rt reflect.Type, err error) error {
+	var ee *EncodeError
+	if errors.As(err, &ee) {
+		return err
+	}
+	return &EncodeError{Type: rt, Err: err}
+}
+
+// withDecodePath prepends the path segment to the *DecodeError in the err.
+// The field is set if the *DecodeError has no field yet.
+func withDecodePath(err error, segment, field string) error {
+	var de *DecodeError
+	if errors.As(err, &de) {
+		de.Path = joinPath(segment, de.Path)
+		if de.Field == "" {
+			de.Field = field
+		}
+	}
+	return err
+}
+
+// withEncodePath prepends the path segment to the *EncodeError in the err.
+// The field is set if the *EncodeError has no field yet.
+func withEncodePath(err error, segment, field string) error {
+	var ee *EncodeError
+	if errors.As(err, &ee) {
+		ee.Path = joinPath(segment, ee.Path)
+		if ee.Field == "" {
+			ee.Field = field
+		}
+	}
+	return err
+}
+
+// indexSegment returns the path segment of the list element.
+func indexSegment(i int) string {
+	return "[" + strconv.Itoa(i) + "]"
+}
+
+// joinPath prepends the segment to the path. e.g. `items` and `[3].zip` to `items[3].zip`
+func joinPath(segment, path string) string {
+	if path == "" || strings.HasPrefix(path, "[") {
+		return segment + path
+	}
+	if segment == "" {
+		return path
+	}
+	return segment + "." + path
+}
+
+// expectedAttributeType returns the DynamoDB type expected by the Go type.
+func expectedAttributeType(rt reflect.Type) string {
+	if rt == nil {
+		return ""
+	}
+	switch rt.Kind() {
+	case reflect.String:
+		return "S"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "N"
+	case reflect.Bool:
+		return "BOOL"
+	case reflect.Slice, reflect.Array:
+		if rt.Elem().Kind() == reflect.Uint8 {
+			return "B"
+		}
+		return "L"
+	case reflect.Map, reflect.Struct:
+		return "M"
+	case reflect.Pointer:
+		return expectedAttributeType(rt.Elem())
+	}
+	return ""
+}
+
+// actualAttributeType returns the DynamoDB type of the value, or the Go type if the value is from the database/sql driver.
+func actualAttributeType(value interface{}) string {
+	switch value.(type) {
+	case *types.AttributeValueMemberS:
+		return "S"
+	case *types.AttributeValueMemberN:
+		return "N"
+	case *types.AttributeValueMemberB:
+		return "B"
+	case *types.AttributeValueMemberBOOL:
+		return "BOOL"
+	case *types.AttributeValueMemberNULL:
+		return "NULL"
+	case *types.AttributeValueMemberSS:
+		return "SS"
+	case *types.AttributeValueMemberNS:
+		return "NS"
+	case *types.AttributeValueMemberBS:
+		return "BS"
+	case *types.AttributeValueMemberL:
+		return "L"
+	case *types.AttributeValueMemberM:
+		return "M"
+	}
+	return fmt.Sprintf("%T", value)
+}
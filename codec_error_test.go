@@ -0,0 +1,98 @@
+package sqldav
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"reflect"
+	"testing"
+)
+
+type orderItem struct {
+	Name    string
+	Address Address
+}
+
+type order struct {
+	Items TypedList[orderItem]
+}
+
+func TestDecodeError(t *testing.T) {
+	item := func(zip types.AttributeValue) types.AttributeValue {
+		return &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"name": &types.AttributeValueMemberS{Value: "foo"},
+			"address": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"zip": zip,
+			}},
+		}}
+	}
+	av := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"items": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			item(&types.AttributeValueMemberS{Value: "100-0001"}),
+			item(&types.AttributeValueMemberS{Value: "100-0002"}),
+			item(&types.AttributeValueMemberS{Value: "100-0003"}),
+			item(&types.AttributeValueMemberN{Value: "1000004"}),
+		}},
+	}}
+	var got order
+	err := Unmarshal(av, &got)
+	if !errors.Is(err, ErrNestedStructHasIncompatibleAttributes) {
+		t.Errorf("Unmarshal() error = %v, want %v", err, ErrNestedStructHasIncompatibleAttributes)
+	}
+	if !errors.Is(err, ErrFailedToCast) {
+		t.Errorf("Unmarshal() error = %v, want %v", err, ErrFailedToCast)
+	}
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("Unmarshal() error = %v, want *DecodeError", err)
+	}
+	want := DecodeError{
+		Path:     "items[3].address.zip",
+		Type:     reflect.TypeOf(""),
+		Field:    "Address.Zip",
+		Expected: "S",
+		Actual:   "N",
+	}
+	if de.Path != want.Path || de.Type != want.Type || de.Field != want.Field || de.Expected != want.Expected || de.Actual != want.Actual {
+		t.Errorf("DecodeError = %+v, want %+v", *de, want)
+	}
+	if de.Error() != `sqldav: cannot decode items[3].address.zip of N into string (Address.Zip), expected S: nested struct has incompatible attributes
+incompatible string and *types.AttributeValueMemberN` {
+		t.Errorf("DecodeError.Error() = %q", de.Error())
+	}
+}
+
+func TestDecodeError_UnknownAttribute(t *testing.T) {
+	av := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"address": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"country": &types.AttributeValueMemberS{Value: "JP"},
+		}},
+	}}
+	err := NewDecoder(WithDisallowUnknownAttributes()).Decode(av, &orderItem{})
+	var de *DecodeError
+	if !errors.As(err, &de) || !errors.Is(err, ErrUnknownAttribute) {
+		t.Fatalf("Decode() error = %v, want *DecodeError of %v", err, ErrUnknownAttribute)
+	}
+	if de.Path != "address.country" {
+		t.Errorf("DecodeError.Path = %v, want %v", de.Path, "address.country")
+	}
+}
+
+func TestEncodeError(t *testing.T) {
+	type item struct {
+		Roles Set[string]
+	}
+	v := map[string]interface{}{
+		"items": []item{{Roles: Set[string]{"a"}}, {Roles: Set[string]{""}}},
+	}
+	_, err := Marshal(v)
+	if !errors.Is(err, ErrSetContainsEmptyString) {
+		t.Errorf("Marshal() error = %v, want %v", err, ErrSetContainsEmptyString)
+	}
+	var ee *EncodeError
+	if !errors.As(err, &ee) {
+		t.Fatalf("Marshal() error = %v, want *EncodeError", err)
+	}
+	if ee.Path != "items[1].roles" || ee.Type != reflect.TypeOf(Set[string]{}) || ee.Field != "item.Roles" {
+		t.Errorf("EncodeError = %+v", *ee)
+	}
+}
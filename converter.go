@@ -0,0 +1,97 @@
+package sqldav
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// converter converts between the Go values of a specific type and types.AttributeValue.
+// Either function may be nil, in which case the direction falls back to the default conversion.
+type converter struct {
+	encode func(rv reflect.Value) (types.AttributeValue, error)
+	decode func(av types.AttributeValue, rv reflect.Value) error
+}
+
+// converters are the converters per Go type.
+type converters map[reflect.Type]converter
+
+// newConverter returns the converter that calls the typed functions.
+func newConverter[T any](encode func(T) (types.AttributeValue, error), decode func(types.AttributeValue) (T, error)) (reflect.Type, converter) {
+	var c converter
+	if encode != nil {
+		c.encode = func(rv reflect.Value) (types.AttributeValue, error) {
+			return encode(rv.Interface().(T))
+		}
+	}
+	if decode != nil {
+		c.decode = func(av types.AttributeValue, rv reflect.Value) error {
+			v, err := decode(av)
+			if err != nil {
+				return err
+			}
+			rv.Set(reflect.ValueOf(&v).Elem())
+			return nil
+		}
+	}
+	return reflect.TypeOf((*T)(nil)).Elem(), c
+}
+
+var (
+	// globalConverters holds the converters registered by RegisterConverter. It is replaced on every registration.
+	globalConverters atomic.Pointer[converters]
+	// globalConvertersMu serializes the registrations.
+	globalConvertersMu sync.Mutex
+)
+
+// RegisterConverter registers the functions that convert between T and types.AttributeValue
+// for every Encoder and Decoder, including the ones used by Marshal, Unmarshal and the sqldav types.
+//
+// The converter is consulted for T wherever it appears: struct fields, elements of List and TypedList,
+// and values of Map and TypedMap. It takes precedence over driver.Valuer and sql.Scanner of T,
+// and embedded structs of T are not flattened.
+// decode is called with NULL too, and the values from the database/sql driver, such as the elements of []interface{},
+// are converted to types.AttributeValue before decode is called. Pass nil for either function to keep the default conversion.
+//
+// Registering T again replaces its converter. Converters set by WithConverter take precedence.
+func RegisterConverter[T any](encode func(T) (types.AttributeValue, error), decode func(types.AttributeValue) (T, error)) {
+	rt, c := newConverter(encode, decode)
+	globalConvertersMu.Lock()
+	defer globalConvertersMu.Unlock()
+	next := converters{}
+	if current := globalConverters.Load(); current != nil {
+		for k, v := range *current {
+			next[k] = v
+		}
+	}
+	next[rt] = c
+	globalConverters.Store(&next)
+}
+
+// WithConverter sets the functions that convert between T and types.AttributeValue, like RegisterConverter,
+// but only for the Encoder or the Decoder.
+func WithConverter[T any](encode func(T) (types.AttributeValue, error), decode func(types.AttributeValue) (T, error)) Option {
+	rt, c := newConverter(encode, decode)
+	return fieldOption(func(fc *fieldConfig) {
+		next := make(converters, len(fc.converters)+1)
+		for k, v := range fc.converters {
+			next[k] = v
+		}
+		next[rt] = c
+		fc.converters = next
+	})
+}
+
+// converterOf returns the converter for the type, preferring the converters of the Encoder or the Decoder
+// to the ones registered by RegisterConverter.
+// The functions of the converter are nil if the type has no converter.
+func (c *fieldConfig) converterOf(rt reflect.Type) converter {
+	if conv, ok := c.converters[rt]; ok {
+		return conv
+	}
+	if global := globalConverters.Load(); global != nil {
+		return (*global)[rt]
+	}
+	return converter{}
+}
@@ -0,0 +1,249 @@
+package sqldav
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+// registerTimeConverter registers the converter of time.Time in RFC 3339 until the end of the test.
+func registerTimeConverter(t *testing.T) {
+	t.Helper()
+	prev := globalConverters.Load()
+	t.Cleanup(func() {
+		globalConverters.Store(prev)
+	})
+	RegisterConverter(
+		func(v time.Time) (types.AttributeValue, error) {
+			return &types.AttributeValueMemberS{Value: v.Format(time.RFC3339)}, nil
+		},
+		func(av types.AttributeValue) (time.Time, error) {
+			s, ok := av.(*types.AttributeValueMemberS)
+			if !ok {
+				return time.Time{}, fmt.Errorf("incompatible time.Time and %T", av)
+			}
+			return time.Parse(time.RFC3339, s.Value)
+		},
+	)
+}
+
+type event struct {
+	Name       string
+	OccurredAt time.Time
+	Retries    TypedList[time.Time]
+	Deadline   *time.Time
+}
+
+type embeddedTime struct {
+	time.Time
+	Name string
+}
+
+var converterTime = time.Date(2024, 10, 6, 12, 0, 0, 0, time.UTC)
+
+func TestRegisterConverter_Marshal(t *testing.T) {
+	registerTimeConverter(t)
+	ts := &types.AttributeValueMemberS{Value: "2024-10-06T12:00:00Z"}
+	type test struct {
+		args interface{}
+		want types.AttributeValue
+	}
+	tests := map[string]test{
+		"struct": {
+			args: event{Name: "deploy", OccurredAt: converterTime, Retries: TypedList[time.Time]{converterTime}, Deadline: &converterTime},
+			want: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"name":        &types.AttributeValueMemberS{Value: "deploy"},
+				"occurred_at": ts,
+				"retries":     &types.AttributeValueMemberL{Value: []types.AttributeValue{ts}},
+				"deadline":    ts,
+			}},
+		},
+		"list": {
+			args: List{converterTime},
+			want: &types.AttributeValueMemberL{Value: []types.AttributeValue{ts}},
+		},
+		"map": {
+			args: Map{"at": converterTime},
+			want: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"at": ts}},
+		},
+		"typed-map": {
+			args: TypedMap[time.Time]{"at": converterTime},
+			want: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"at": ts}},
+		},
+		"embedded-struct-is-not-flattened": {
+			args: embeddedTime{Time: converterTime, Name: "deploy"},
+			want: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"time": ts,
+				"name": &types.AttributeValueMemberS{Value: "deploy"},
+			}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Marshal(tt.args)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, attributeValueCmpOptions()...); diff != "" {
+				t.Errorf("Marshal() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRegisterConverter_Unmarshal(t *testing.T) {
+	registerTimeConverter(t)
+	av := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"name":        &types.AttributeValueMemberS{Value: "deploy"},
+		"occurred_at": &types.AttributeValueMemberS{Value: "2024-10-06T12:00:00Z"},
+		"retries": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberS{Value: "2024-10-06T12:00:00Z"},
+		}},
+		"deadline": &types.AttributeValueMemberNULL{Value: true},
+	}}
+	var got event
+	if err := Unmarshal(av, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := event{Name: "deploy", OccurredAt: converterTime, Retries: TypedList[time.Time]{converterTime}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRegisterConverter_Scan(t *testing.T) {
+	registerTimeConverter(t)
+	var got TypedList[event]
+	err := got.Scan([]interface{}{
+		map[string]interface{}{
+			"name":        "deploy",
+			"occurred_at": "2024-10-06T12:00:00Z",
+			"retries":     []interface{}{"2024-10-06T12:00:00Z"},
+			"deadline":    "2024-10-06T12:00:00Z",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	want := TypedList[event]{{Name: "deploy", OccurredAt: converterTime, Retries: TypedList[time.Time]{converterTime}, Deadline: &converterTime}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRegisterConverter_Error(t *testing.T) {
+	registerTimeConverter(t)
+	var got event
+	err := Unmarshal(&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"retries": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberN{Value: "1"},
+		}},
+	}}, &got)
+	var de *DecodeError
+	if !errors.As(err, &de) || de.Path != "retries[0]" {
+		t.Errorf("Unmarshal() error = %v, want the path retries[0]", err)
+	}
+}
+
+type deviceID [16]byte
+
+type device struct {
+	ID   deviceID
+	Addr netip.Addr
+}
+
+func TestWithConverter(t *testing.T) {
+	opts := []Option{
+		WithConverter(
+			func(v deviceID) (types.AttributeValue, error) {
+				return &types.AttributeValueMemberS{Value: hex.EncodeToString(v[:])}, nil
+			},
+			func(av types.AttributeValue) (deviceID, error) {
+				var id deviceID
+				s, _ := av.(*types.AttributeValueMemberS)
+				if s == nil {
+					return id, fmt.Errorf("incompatible deviceID and %T", av)
+				}
+				_, err := hex.Decode(id[:], []byte(s.Value))
+				return id, err
+			},
+		),
+		WithConverter(
+			func(v netip.Addr) (types.AttributeValue, error) {
+				return &types.AttributeValueMemberS{Value: v.String()}, nil
+			},
+			func(av types.AttributeValue) (netip.Addr, error) {
+				s, _ := av.(*types.AttributeValueMemberS)
+				if s == nil {
+					return netip.Addr{}, fmt.Errorf("incompatible netip.Addr and %T", av)
+				}
+				return netip.ParseAddr(s.Value)
+			},
+		),
+	}
+	v := device{ID: deviceID{0: 0xab, 15: 0xcd}, Addr: netip.MustParseAddr("192.0.2.1")}
+	want := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"id":   &types.AttributeValueMemberS{Value: "ab0000000000000000000000000000cd"},
+		"addr": &types.AttributeValueMemberS{Value: "192.0.2.1"},
+	}}
+
+	av, err := NewEncoder(encoderOptions(opts)...).Encode(v)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if diff := cmp.Diff(want, av, attributeValueCmpOptions()...); diff != "" {
+		t.Errorf("Encode() mismatch (-want +got):\n%s", diff)
+	}
+	var got device
+	if err := NewDecoder(decoderOptions(opts)...).Decode(av, &got); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if diff := cmp.Diff(v, got, cmp.Comparer(func(a, b netip.Addr) bool { return a == b })); diff != "" {
+		t.Errorf("Decode() mismatch (-want +got):\n%s", diff)
+	}
+
+	// the default Encoder is not affected.
+	av, err = Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if _, ok := av.(*types.AttributeValueMemberM).Value["id"].(*types.AttributeValueMemberS); ok {
+		t.Errorf("Marshal() = %v, want the default conversion of [16]byte", av)
+	}
+}
+
+func encoderOptions(opts []Option) []EncoderOption {
+	eo := make([]EncoderOption, 0, len(opts))
+	for _, o := range opts {
+		eo = append(eo, o)
+	}
+	return eo
+}
+
+func decoderOptions(opts []Option) []DecoderOption {
+	do := make([]DecoderOption, 0, len(opts))
+	for _, o := range opts {
+		do = append(do, o)
+	}
+	return do
+}
+
+func attributeValueCmpOptions() []cmp.Option {
+	return []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberN{}),
+		cmp.AllowUnexported(types.AttributeValueMemberL{}),
+		cmp.AllowUnexported(types.AttributeValueMemberM{}),
+		cmp.AllowUnexported(types.AttributeValueMemberNULL{}),
+		cmp.AllowUnexported(types.AttributeValueMemberB{}),
+		cmp.AllowUnexported(types.AttributeValueMemberBOOL{}),
+		cmp.AllowUnexported(types.AttributeValueMemberSS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberNS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberBS{}),
+	}
+}
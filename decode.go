@@ -0,0 +1,740 @@
+package sqldav
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrInvalidUnmarshalTarget occurs when the target of the Unmarshal is not a non-nil pointer.
+	ErrInvalidUnmarshalTarget = errors.New("unmarshal target must be a non-nil pointer")
+	// ErrUnknownAttribute occurs when the map has an attribute that has no corresponding struct field.
+	ErrUnknownAttribute = errors.New("unknown attribute")
+	// ErrMissingAttribute occurs when the map has no attribute for the struct field with the required option.
+	ErrMissingAttribute = errors.New("missing attribute")
+	// ErrUnsupportedType occurs when the Go type cannot be decoded, such as channels and functions.
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+// NullHandling specifies how the Decoder handles NULL for the types that cannot be nil.
+//
+// NULL is always assigned as nil to pointers and interfaces.
+type NullHandling int
+
+const (
+	// NullIsIncompatible returns an error. This is the default.
+	NullIsIncompatible NullHandling = iota
+	// NullAsZero assigns the zero value.
+	NullAsZero
+	// NullAsMissing leaves the destination as it is, as if the attribute were missing.
+	NullAsMissing
+)
+
+// Decoder converts types.AttributeValue and the values from the database/sql driver to Go values.
+type Decoder struct {
+	fieldConfig
+	disallowUnknownAttributes bool
+	nullHandling              NullHandling
+	useNumber                 bool
+	driverAdapter             DriverAdapter
+}
+
+// DecoderOption configures the Decoder.
+type DecoderOption interface {
+	applyToDecoder(d *Decoder)
+}
+
+// decoderOptionFunc is a DecoderOption that configures the Decoder by function.
+type decoderOptionFunc func(d *Decoder)
+
+func (f decoderOptionFunc) applyToDecoder(d *Decoder) {
+	f(d)
+}
+
+// WithDisallowUnknownAttributes returns ErrUnknownAttribute if the map has an attribute that has no corresponding struct field.
+func WithDisallowUnknownAttributes() DecoderOption {
+	return decoderOptionFunc(func(d *Decoder) {
+		d.disallowUnknownAttributes = true
+	})
+}
+
+// WithNullHandling sets how the Decoder handles NULL for the types that cannot be nil.
+func WithNullHandling(h NullHandling) DecoderOption {
+	return decoderOptionFunc(func(d *Decoder) {
+		d.nullHandling = h
+	})
+}
+
+// WithUseNumber decodes numbers into interface{} as Number instead of float64, so that no precision is lost.
+func WithUseNumber() DecoderOption {
+	return decoderOptionFunc(func(d *Decoder) {
+		d.useNumber = true
+	})
+}
+
+// NewDecoder returns a new Decoder.
+func NewDecoder(opts ...DecoderOption) *Decoder {
+	d := &Decoder{fieldConfig: newFieldConfig()}
+	for _, opt := range opts {
+		opt.applyToDecoder(d)
+	}
+	return d
+}
+
+// defaultDecoder holds the Decoder used by Unmarshal and the sqldav types.
+var defaultDecoder atomic.Pointer[Decoder]
+
+func init() {
+	defaultDecoder.Store(NewDecoder())
+}
+
+// SetDefaultDecoderOptions sets the options of the Decoder used by Unmarshal and the Scan methods of the sqldav types.
+//
+// e.g. SetDefaultDecoderOptions(WithDisallowUnknownAttributes()) makes every TypedList.Scan strict.
+func SetDefaultDecoderOptions(opts ...DecoderOption) {
+	defaultDecoder.Store(NewDecoder(opts...))
+}
+
+// Unmarshal converts the types.AttributeValue to the Go value pointed to by v with the default Decoder.
+func Unmarshal(av types.AttributeValue, v interface{}) error {
+	return defaultDecoder.Load().Decode(av, v)
+}
+
+// Decode converts the types.AttributeValue to the Go value pointed to by v.
+//
+// Maps are converted to structs whose fields are resolved by the struct tags and the NamingStrategy.
+func (d *Decoder) Decode(av types.AttributeValue, v interface{}) error {
+	return d.DecodeValue(av, v)
+}
+
+// DecodeValue converts the value to the Go value pointed to by v.
+//
+// The value is either a types.AttributeValue or a value from the database/sql driver, such as Map and []interface{}.
+// e.g. DecodeValue(value, &typedList) is TypedList.Scan with the Decoder.
+// The value from the driver is normalized by the DriverAdapter set by WithDriverAdapter.
+func (d *Decoder) DecodeValue(value interface{}, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.Join(ErrInvalidUnmarshalTarget, fmt.Errorf("got %T", v))
+	}
+	value, err := d.adapt(value)
+	if err != nil {
+		return err
+	}
+	return d.decode(rv.Elem().Type(), rv.Elem(), value)
+}
+
+// documentDecoder is implemented by the sqldav types to assign the value to themselves with the Decoder.
+//
+// The value is either a types.AttributeValue or a value from the database/sql driver.
+type documentDecoder interface {
+	decodeWith(d *Decoder, value interface{}) error
+}
+
+// decode assigns the value to the reflect.Value
+//
+// The value is either a types.AttributeValue or a value from the database/sql driver.
+// The error is a *DecodeError, or wraps one.
+func (d *Decoder) decode(rt reflect.Type, rv reflect.Value, value interface{}) error {
+	err := d.decodeValue(rt, rv, value)
+	if err != nil {
+		return newDecodeError(rt, value, err)
+	}
+	return nil
+}
+
+// decodesItselfTypes caches the result of decodesItself per reflect.Type.
+var decodesItselfTypes sync.Map
+
+// decodesItself reports whether the type, or the pointer to it, implements documentDecoder, sql.Scanner,
+// AttributeValueUnmarshaler or attributevalue.Unmarshaler.
+// It is computed once per type and cached.
+func decodesItself(rt reflect.Type) bool {
+	if ok, cached := decodesItselfTypes.Load(rt); cached {
+		return ok.(bool)
+	}
+	pt := reflect.PointerTo(rt)
+	ok := rt.Implements(documentDecoderType) || rt.Implements(scannerType) || implementsUnmarshaler(rt) ||
+		pt.Implements(documentDecoderType) || pt.Implements(scannerType) || implementsUnmarshaler(pt)
+	decodesItselfTypes.Store(rt, ok)
+	return ok
+}
+
+// decodeValue assigns the value to the reflect.Value
+func (d *Decoder) decodeValue(rt reflect.Type, rv reflect.Value, value interface{}) error {
+	if c := d.converterOf(rt); c.decode != nil {
+		if rv.Kind() == reflect.Pointer && rt.Kind() != reflect.Pointer {
+			rv = rv.Elem()
+		}
+		av, err := attributeValueOf(value)
+		if err != nil {
+			return err
+		}
+		return c.decode(av, rv)
+	}
+	if decodesItself(rv.Type()) {
+		var target interface{}
+		if rv.CanAddr() {
+			target = rv.Addr().Interface()
+		} else {
+			target = rv.Interface()
+		}
+		switch sc := target.(type) {
+		case AttributeValueUnmarshaler:
+			av, err := attributeValueOf(value)
+			if err != nil {
+				return err
+			}
+			return sc.UnmarshalAttributeValue(av)
+		case documentDecoder:
+			return sc.decodeWith(d, value)
+		case sql.Scanner:
+			return scanDriverValue(sc, value)
+		case attributevalue.Unmarshaler:
+			av, err := attributeValueOf(value)
+			if err != nil {
+				return err
+			}
+			return sc.UnmarshalDynamoDBAttributeValue(av)
+		}
+	}
+	if rv.Kind() == reflect.Pointer && rt.Kind() != reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if isNull(value) {
+		switch rt.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			rv.Set(reflect.Zero(rt))
+			return nil
+		}
+		switch d.nullHandling {
+		case NullAsZero:
+			rv.Set(reflect.Zero(rt))
+			return nil
+		case NullAsMissing:
+			return nil
+		}
+	}
+	switch rt.Kind() {
+	case reflect.String:
+		str, ok := stringOf(value)
+		if !ok {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
+				fmt.Errorf("incompatible string and %T", value))
+		}
+		rv.SetString(str)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := numberOf(value)
+		if !ok {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
+				fmt.Errorf("incompatible %v and %T", rt, value))
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes, err)
+		}
+		if rv.OverflowInt(i) {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes, ErrNumberOverflow,
+				fmt.Errorf("%s overflows %v", n, rt))
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := numberOf(value)
+		if !ok {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
+				fmt.Errorf("incompatible %v and %T", rt, value))
+		}
+		u, err := n.Uint64()
+		if err != nil {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes, err)
+		}
+		if rv.OverflowUint(u) {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes, ErrNumberOverflow,
+				fmt.Errorf("%s overflows %v", n, rt))
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch value := value.(type) {
+		case float64:
+			f = value
+		case float32:
+			f = float64(value)
+		case Number, *types.AttributeValueMemberN:
+			n, _ := numberOf(value)
+			v, err := n.Float64()
+			if err != nil {
+				return errors.Join(ErrNestedStructHasIncompatibleAttributes, err)
+			}
+			f = v
+		default:
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
+				fmt.Errorf("incompatible %v and %T", rt, value))
+		}
+		if rv.OverflowFloat(f) {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes, ErrNumberOverflow,
+				fmt.Errorf("%v overflows %v", f, rt))
+		}
+		rv.SetFloat(f)
+	case reflect.Bool:
+		b, ok := boolOf(value)
+		if !ok {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
+				fmt.Errorf("incompatible bool and %T", value))
+		}
+		rv.SetBool(b)
+	case reflect.Slice:
+		if rt.Elem().Kind() == reflect.Uint8 {
+			if b, ok := bytesOf(value); ok {
+				rv.SetBytes(b)
+				break
+			}
+		}
+		ev, ok := elementsOf(value)
+		if !ok {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
+				fmt.Errorf("incompatible %v and %T", rt, value))
+		}
+		sv := reflect.MakeSlice(rt, len(ev), len(ev))
+		for i, v := range ev {
+			err := d.decode(rt.Elem(), sv.Index(i), v)
+			if err != nil {
+				return withDecodePath(err, indexSegment(i), "")
+			}
+		}
+		rv.Set(sv)
+	case reflect.Array:
+		ev, ok := elementsOf(value)
+		if !ok {
+			b, isBytes := bytesOf(value)
+			if !isBytes || rt.Elem().Kind() != reflect.Uint8 {
+				return errors.Join(ErrNestedStructHasIncompatibleAttributes,
+					fmt.Errorf("incompatible %v and %T", rt, value))
+			}
+			ev = make([]interface{}, 0, len(b))
+			for _, v := range b {
+				ev = append(ev, float64(v))
+			}
+		}
+		if len(ev) > rt.Len() {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
+				fmt.Errorf("%d elements overflow %v", len(ev), rt))
+		}
+		av := reflect.New(rt).Elem()
+		for i, v := range ev {
+			err := d.decode(rt.Elem(), av.Index(i), v)
+			if err != nil {
+				return withDecodePath(err, indexSegment(i), "")
+			}
+		}
+		rv.Set(av)
+	case reflect.Map:
+		if rt.Key().Kind() != reflect.String {
+			return errors.Join(ErrUnsupportedType, fmt.Errorf("map key of %v", rt))
+		}
+		mv, ok := mapOf(value)
+		if !ok {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
+				fmt.Errorf("incompatible %v and %T", rt, value))
+		}
+		m := reflect.MakeMapWithSize(rt, len(mv))
+		for k, v := range mv {
+			ev := reflect.New(rt.Elem()).Elem()
+			err := d.decode(rt.Elem(), ev, v)
+			if err != nil {
+				return withDecodePath(err, k, "")
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(rt.Key()), ev)
+		}
+		rv.Set(m)
+	case reflect.Struct:
+		mv, ok := mapOf(value)
+		if !ok {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
+				fmt.Errorf("incompatible struct and %T", value))
+		}
+		err := d.decodeStruct(rt, rv, mv)
+		if err != nil {
+			return err
+		}
+	case reflect.Interface:
+		v, err := d.interfaceOf(value)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			rv.Set(reflect.Zero(rt))
+			return nil
+		}
+		if !reflect.TypeOf(v).AssignableTo(rt) {
+			return errors.Join(ErrNestedStructHasIncompatibleAttributes,
+				fmt.Errorf("incompatible %v and %T", rt, v))
+		}
+		rv.Set(reflect.ValueOf(v))
+	case reflect.Pointer:
+		pv := reflect.New(rt.Elem())
+		err := d.decode(rt.Elem(), pv.Elem(), value)
+		if err != nil {
+			return err
+		}
+		rv.Set(pv)
+	default:
+		return errors.Join(ErrUnsupportedType, fmt.Errorf("%v", rt))
+	}
+	return nil
+}
+
+// decodeStruct assigns the map to the struct
+func (d *Decoder) decodeStruct(rt reflect.Type, rv reflect.Value, mv map[string]interface{}) error {
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	fields := d.structFieldsOf(rt)
+	var known map[string]struct{}
+	if d.disallowUnknownAttributes {
+		known = make(map[string]struct{}, len(fields))
+	}
+	for _, f := range fields {
+		if known != nil {
+			known[f.tag.Name] = struct{}{}
+		}
+		a, ok := mv[f.tag.Name]
+		if !ok {
+			if f.tag.Required {
+				return &DecodeError{
+					Path:     f.tag.Name,
+					Type:     f.typ,
+					Field:    f.field,
+					Expected: expectedAttributeType(f.typ),
+					Err:      errors.Join(ErrMissingAttribute, fmt.Errorf("%q is required by %v", f.tag.Name, rt)),
+				}
+			}
+			continue
+		}
+		fv := allocFieldByIndex(rv, f.index)
+		err := d.decode(f.typ, fv, a)
+		if err != nil {
+			return withDecodePath(err, f.tag.Name, f.field)
+		}
+	}
+	for name := range mv {
+		if known == nil {
+			break
+		}
+		if _, ok := known[name]; !ok {
+			return &DecodeError{
+				Path:   name,
+				Type:   rt,
+				Actual: actualAttributeType(mv[name]),
+				Err:    errors.Join(ErrUnknownAttribute, fmt.Errorf("%q has no corresponding field in %v", name, rt)),
+			}
+		}
+	}
+	return nil
+}
+
+// allocFieldByIndex returns the nested field of the struct, allocating nil pointers to embedded structs on the way.
+func allocFieldByIndex(rv reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv
+}
+
+// interfaceOf returns the natural Go value of the value.
+func (d *Decoder) interfaceOf(value interface{}) (interface{}, error) {
+	av, ok := value.(types.AttributeValue)
+	if !ok {
+		return value, nil
+	}
+	if d.useNumber {
+		return attributeValueToInterface(av, true)
+	}
+	return attributeValueToInterface(av, false)
+}
+
+// scanDriverValue scans the value with the sql.Scanner.
+// types.AttributeValue is converted to the value from the database/sql driver.
+func scanDriverValue(sc sql.Scanner, value interface{}) error {
+	value, err := driverValueOf(value)
+	if err != nil {
+		return err
+	}
+	return sc.Scan(value)
+}
+
+// driverValueOf converts the value to the value from the database/sql driver if it is a types.AttributeValue.
+// Numbers are converted to float64 as the driver does.
+func driverValueOf(value interface{}) (interface{}, error) {
+	if av, ok := value.(types.AttributeValue); ok {
+		return attributeValueToInterface(av, false)
+	}
+	return value, nil
+}
+
+// attributeValueToInterface converts the types.AttributeValue to the value from the database/sql driver.
+//
+// If useNumber is true, numbers are converted to Number instead of float64.
+func attributeValueToInterface(av types.AttributeValue, useNumber bool) (interface{}, error) {
+	switch av := av.(type) {
+	case *types.AttributeValueMemberS:
+		return av.Value, nil
+	case *types.AttributeValueMemberN:
+		if useNumber {
+			return Number(av.Value), nil
+		}
+		return strconv.ParseFloat(av.Value, 64)
+	case *types.AttributeValueMemberB:
+		return av.Value, nil
+	case *types.AttributeValueMemberBOOL:
+		return av.Value, nil
+	case *types.AttributeValueMemberNULL:
+		return nil, nil
+	case *types.AttributeValueMemberSS:
+		return av.Value, nil
+	case *types.AttributeValueMemberNS:
+		if useNumber {
+			ns := make([]Number, 0, len(av.Value))
+			for _, v := range av.Value {
+				ns = append(ns, Number(v))
+			}
+			return ns, nil
+		}
+		fs := make([]float64, 0, len(av.Value))
+		for _, v := range av.Value {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, err
+			}
+			fs = append(fs, f)
+		}
+		return fs, nil
+	case *types.AttributeValueMemberBS:
+		return av.Value, nil
+	case *types.AttributeValueMemberL:
+		l := make([]interface{}, 0, len(av.Value))
+		for _, v := range av.Value {
+			iv, err := attributeValueToInterface(v, useNumber)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, iv)
+		}
+		return l, nil
+	case *types.AttributeValueMemberM:
+		m := make(map[string]interface{}, len(av.Value))
+		for k, v := range av.Value {
+			iv, err := attributeValueToInterface(v, useNumber)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = iv
+		}
+		return m, nil
+	}
+	return nil, errors.Join(ErrFailedToCast, fmt.Errorf("unsupported attribute value %T", av))
+}
+
+// attributeValueOf converts the value from the database/sql driver to the types.AttributeValue.
+// It is the inverse of attributeValueToInterface.
+func attributeValueOf(value interface{}) (types.AttributeValue, error) {
+	switch value := value.(type) {
+	case types.AttributeValue:
+		return value, nil
+	case nil:
+		return newNullAttributeValue(), nil
+	case []string:
+		return &types.AttributeValueMemberSS{Value: value}, nil
+	case [][]byte:
+		return &types.AttributeValueMemberBS{Value: value}, nil
+	case []float64:
+		ns := make([]string, 0, len(value))
+		for _, f := range value {
+			ns = append(ns, strconv.FormatFloat(f, 'f', -1, 64))
+		}
+		return &types.AttributeValueMemberNS{Value: ns}, nil
+	case []Number:
+		ns := make([]string, 0, len(value))
+		for _, n := range value {
+			ns = append(ns, string(n))
+		}
+		return &types.AttributeValueMemberNS{Value: ns}, nil
+	case []interface{}:
+		avl := make([]types.AttributeValue, 0, len(value))
+		for _, v := range value {
+			av, err := attributeValueOf(v)
+			if err != nil {
+				return nil, err
+			}
+			avl = append(avl, av)
+		}
+		return &types.AttributeValueMemberL{Value: avl}, nil
+	case map[string]interface{}:
+		avm := make(map[string]types.AttributeValue, len(value))
+		for k, v := range value {
+			av, err := attributeValueOf(v)
+			if err != nil {
+				return nil, err
+			}
+			avm[k] = av
+		}
+		return &types.AttributeValueMemberM{Value: avm}, nil
+	}
+	return defaultEncoder.encode(value)
+}
+
+// isNull reports whether the value is NULL.
+func isNull(value interface{}) bool {
+	switch value.(type) {
+	case nil, *types.AttributeValueMemberNULL:
+		return true
+	}
+	return false
+}
+
+// stringOf returns the string of the value.
+func stringOf(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case *types.AttributeValueMemberS:
+		return value.Value, true
+	}
+	return "", false
+}
+
+// numberOf returns the Number of the value.
+func numberOf(value interface{}) (Number, bool) {
+	switch value := value.(type) {
+	case Number:
+		return value, true
+	case *types.AttributeValueMemberN:
+		return Number(value.Value), true
+	case float64:
+		return Number(strconv.FormatFloat(value, 'g', -1, 64)), true
+	case float32:
+		return Number(strconv.FormatFloat(float64(value), 'g', -1, 32)), true
+	case int64:
+		return Number(strconv.FormatInt(value, 10)), true
+	}
+	return "", false
+}
+
+// boolOf returns the bool of the value.
+func boolOf(value interface{}) (bool, bool) {
+	switch value := value.(type) {
+	case bool:
+		return value, true
+	case *types.AttributeValueMemberBOOL:
+		return value.Value, true
+	}
+	return false, false
+}
+
+// bytesOf returns the []byte of the value.
+func bytesOf(value interface{}) ([]byte, bool) {
+	switch value := value.(type) {
+	case []byte:
+		return value, true
+	case *types.AttributeValueMemberB:
+		return value.Value, true
+	}
+	return nil, false
+}
+
+// mapOf returns the map of the value.
+// The values of *types.AttributeValueMemberM remain types.AttributeValue.
+func mapOf(value interface{}) (map[string]interface{}, bool) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return value, true
+	case Map:
+		return value, true
+	case *types.AttributeValueMemberM:
+		mv := make(map[string]interface{}, len(value.Value))
+		for k, v := range value.Value {
+			mv[k] = v
+		}
+		return mv, true
+	}
+	return nil, false
+}
+
+// elementsOf returns the elements of the list or the set.
+// The elements of the types.AttributeValue remain types.AttributeValue.
+func elementsOf(value interface{}) ([]interface{}, bool) {
+	if lv, ok := listOf(value); ok {
+		return lv, true
+	}
+	if sa, ok := value.(setAttribute); ok {
+		value = sa.setElements()
+	}
+	var ev []interface{}
+	switch value := value.(type) {
+	case []string:
+		for _, v := range value {
+			ev = append(ev, v)
+		}
+	case []float64:
+		for _, v := range value {
+			ev = append(ev, v)
+		}
+	case [][]byte:
+		for _, v := range value {
+			ev = append(ev, v)
+		}
+	case []Number:
+		for _, v := range value {
+			ev = append(ev, v)
+		}
+	case *types.AttributeValueMemberSS:
+		for _, v := range value.Value {
+			ev = append(ev, &types.AttributeValueMemberS{Value: v})
+		}
+	case *types.AttributeValueMemberNS:
+		for _, v := range value.Value {
+			ev = append(ev, &types.AttributeValueMemberN{Value: v})
+		}
+	case *types.AttributeValueMemberBS:
+		for _, v := range value.Value {
+			ev = append(ev, &types.AttributeValueMemberB{Value: v})
+		}
+	default:
+		return nil, false
+	}
+	if ev == nil {
+		ev = []interface{}{}
+	}
+	return ev, true
+}
+
+// listOf returns the list of the value.
+// The elements of *types.AttributeValueMemberL remain types.AttributeValue.
+func listOf(value interface{}) ([]interface{}, bool) {
+	switch value := value.(type) {
+	case []interface{}:
+		return value, true
+	case List:
+		return value, true
+	case *types.AttributeValueMemberL:
+		lv := make([]interface{}, 0, len(value.Value))
+		for _, v := range value.Value {
+			lv = append(lv, v)
+		}
+		return lv, true
+	}
+	return nil, false
+}
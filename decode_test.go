@@ -0,0 +1,353 @@
+package sqldav
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"strings"
+	"testing"
+)
+
+type decodeTarget struct {
+	UserID   string
+	Nickname *string `db:"nick"`
+	Age      int     `gorm:"column:age_in_years"`
+	Email    string  `xorm:"varchar(255) 'mail_address'"`
+	Roles    Set[string]
+	Address  Document[Address]
+	Ratio    float64
+	Active   bool
+}
+
+func TestUnmarshal(t *testing.T) {
+	type test struct {
+		sut           *Decoder
+		args          types.AttributeValue
+		init          decodeTarget
+		want          error
+		expectedState decodeTarget
+	}
+	tests := map[string]test{
+		"happy-path/struct": {
+			sut: NewDecoder(),
+			args: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"user_id":      &types.AttributeValueMemberS{Value: "u1"},
+				"nick":         &types.AttributeValueMemberS{Value: "foo"},
+				"age_in_years": &types.AttributeValueMemberN{Value: "20"},
+				"mail_address": &types.AttributeValueMemberS{Value: "foo@example.com"},
+				"roles":        &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+				"address": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"zip":   &types.AttributeValueMemberS{Value: "100-0001"},
+					"floor": &types.AttributeValueMemberN{Value: "3"},
+				}},
+				"ratio":  &types.AttributeValueMemberN{Value: "0.5"},
+				"active": &types.AttributeValueMemberBOOL{Value: true},
+			}},
+			expectedState: decodeTarget{
+				UserID:   "u1",
+				Nickname: aws.String("foo"),
+				Age:      20,
+				Email:    "foo@example.com",
+				Roles:    Set[string]{"a", "b"},
+				Address:  Document[Address]{Data: Address{Zip: "100-0001", Floor: 3}},
+				Ratio:    0.5,
+				Active:   true,
+			},
+		},
+		"happy-path/null-to-pointer": {
+			sut: NewDecoder(),
+			args: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"nick": &types.AttributeValueMemberNULL{Value: true},
+			}},
+			init:          decodeTarget{Nickname: aws.String("foo")},
+			expectedState: decodeTarget{},
+		},
+		"happy-path/null-as-zero": {
+			sut: NewDecoder(WithNullHandling(NullAsZero)),
+			args: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"user_id": &types.AttributeValueMemberNULL{Value: true},
+			}},
+			init:          decodeTarget{UserID: "u1"},
+			expectedState: decodeTarget{},
+		},
+		"happy-path/null-as-missing": {
+			sut: NewDecoder(WithNullHandling(NullAsMissing)),
+			args: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"user_id": &types.AttributeValueMemberNULL{Value: true},
+			}},
+			init:          decodeTarget{UserID: "u1"},
+			expectedState: decodeTarget{UserID: "u1"},
+		},
+		"happy-path/unknown-attribute": {
+			sut: NewDecoder(),
+			args: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"unknown": &types.AttributeValueMemberS{Value: "foo"},
+			}},
+			expectedState: decodeTarget{},
+		},
+		"unhappy-path/disallow-unknown-attributes": {
+			sut: NewDecoder(WithDisallowUnknownAttributes()),
+			args: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"unknown": &types.AttributeValueMemberS{Value: "foo"},
+			}},
+			want: ErrUnknownAttribute,
+		},
+		"unhappy-path/null-is-incompatible": {
+			sut: NewDecoder(),
+			args: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"user_id": &types.AttributeValueMemberNULL{Value: true},
+			}},
+			want: ErrNestedStructHasIncompatibleAttributes,
+		},
+		"unhappy-path/incompatible": {
+			sut: NewDecoder(),
+			args: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"age_in_years": &types.AttributeValueMemberS{Value: "20"},
+			}},
+			want: ErrNestedStructHasIncompatibleAttributes,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tt.init
+			err := tt.sut.Decode(tt.args, &got)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Decode() error = %v, want %v", err, tt.want)
+				return
+			}
+			if tt.want != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.expectedState, got); diff != "" {
+				t.Errorf("Decode() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_Interface(t *testing.T) {
+	av := &types.AttributeValueMemberL{Value: []types.AttributeValue{
+		&types.AttributeValueMemberN{Value: "12345678901234567890123456789012345678"},
+		&types.AttributeValueMemberS{Value: "foo"},
+	}}
+	var got interface{}
+	if err := Unmarshal(av, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if diff := cmp.Diff([]interface{}{1.2345678901234568e+37, "foo"}, got); diff != "" {
+		t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+	}
+	got = nil
+	if err := NewDecoder(WithUseNumber()).Decode(av, &got); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if diff := cmp.Diff([]interface{}{Number("12345678901234567890123456789012345678"), "foo"}, got); diff != "" {
+		t.Errorf("Decode() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshal_NamingStrategy(t *testing.T) {
+	av := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"NAME": &types.AttributeValueMemberS{Value: "foo"},
+	}}
+	var got struct{ Name string }
+	if err := NewDecoder(WithNamingStrategy(NamingStrategyFunc(strings.ToUpper))).Decode(av, &got); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got.Name != "foo" {
+		t.Errorf("Decode() = %v, want %v", got.Name, "foo")
+	}
+}
+
+func TestUnmarshal_Number(t *testing.T) {
+	var got struct {
+		Price Number
+	}
+	err := Unmarshal(&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"price": &types.AttributeValueMemberN{Value: "12345678901234567890.5"},
+	}}, &got)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.Price != "12345678901234567890.5" {
+		t.Errorf("Unmarshal() = %v, want the number without loss of precision", got.Price)
+	}
+}
+
+func TestUnmarshal_InvalidTarget(t *testing.T) {
+	var got decodeTarget
+	if err := Unmarshal(&types.AttributeValueMemberM{}, got); !errors.Is(err, ErrInvalidUnmarshalTarget) {
+		t.Errorf("Unmarshal() error = %v, want %v", err, ErrInvalidUnmarshalTarget)
+	}
+	if err := Unmarshal(&types.AttributeValueMemberM{}, (*decodeTarget)(nil)); !errors.Is(err, ErrInvalidUnmarshalTarget) {
+		t.Errorf("Unmarshal() error = %v, want %v", err, ErrInvalidUnmarshalTarget)
+	}
+}
+
+func TestUnmarshal_TagOptions(t *testing.T) {
+	av := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"id":       &types.AttributeValueMemberS{Value: "u1"},
+		"name":     &types.AttributeValueMemberS{Value: "foo"},
+		"age":      &types.AttributeValueMemberN{Value: "20"},
+		"secret":   &types.AttributeValueMemberS{Value: "secret"},
+		"Secret":   &types.AttributeValueMemberS{Value: "secret"},
+		"internal": &types.AttributeValueMemberS{Value: "internal"},
+	}}
+	var got taggedTarget
+	if err := Unmarshal(av, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := taggedTarget{ID: "u1", Name: "foo", Age: 20}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+	}
+	err := NewDecoder(WithDisallowUnknownAttributes()).Decode(av, &taggedTarget{})
+	if !errors.Is(err, ErrUnknownAttribute) {
+		t.Errorf("Decode() error = %v, want %v", err, ErrUnknownAttribute)
+	}
+}
+
+type requiredTarget struct {
+	ID    string `dynamodbav:"id,required"`
+	Name  string `json:",required"`
+	Note  string
+	Tags  []string    `dynamodbav:"tags"`
+	STags Set[string] `dynamodbav:"s_tags"`
+	Nums  []int       `dynamodbav:"nums"`
+}
+
+func TestDecoder_Required(t *testing.T) {
+	type test struct {
+		args          types.AttributeValue
+		want          error
+		path          string
+		expectedState requiredTarget
+	}
+	tests := map[string]test{
+		"happy-path": {
+			args: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"id":   &types.AttributeValueMemberS{Value: "u1"},
+				"name": &types.AttributeValueMemberS{Value: "foo"},
+			}},
+			expectedState: requiredTarget{ID: "u1", Name: "foo"},
+		},
+		"unhappy-path/null-is-not-missing": {
+			args: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"id":   &types.AttributeValueMemberS{Value: "u1"},
+				"name": &types.AttributeValueMemberNULL{Value: true},
+			}},
+			want: ErrNestedStructHasIncompatibleAttributes,
+			path: "name",
+		},
+		"unhappy-path/missing": {
+			args: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"name": &types.AttributeValueMemberS{Value: "foo"},
+			}},
+			want: ErrMissingAttribute,
+			path: "id",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got requiredTarget
+			err := Unmarshal(tt.args, &got)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Unmarshal() error = %v, want %v", err, tt.want)
+				return
+			}
+			if tt.want != nil {
+				var de *DecodeError
+				if !errors.As(err, &de) || de.Path != tt.path {
+					t.Errorf("Unmarshal() error = %v, want path %v", err, tt.path)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.expectedState, got); diff != "" {
+				t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_DecodeValue_Strict(t *testing.T) {
+	strict := NewDecoder(WithDisallowUnknownAttributes())
+	t.Run("typed-list", func(t *testing.T) {
+		var got TypedList[requiredTarget]
+		err := strict.DecodeValue([]interface{}{
+			map[string]interface{}{"id": "u1", "name": "foo"},
+			map[string]interface{}{"id": "u2", "name": "bar", "nickname": "baz"},
+		}, &got)
+		var de *DecodeError
+		if !errors.Is(err, ErrUnknownAttribute) || !errors.As(err, &de) {
+			t.Fatalf("DecodeValue() error = %v, want *DecodeError of %v", err, ErrUnknownAttribute)
+		}
+		if de.Path != "[1].nickname" {
+			t.Errorf("DecodeError.Path = %v, want %v", de.Path, "[1].nickname")
+		}
+	})
+	t.Run("map", func(t *testing.T) {
+		var got requiredTarget
+		err := strict.DecodeValue(Map{"id": "u1", "name": "foo", "nickname": "baz"}, &got)
+		if !errors.Is(err, ErrUnknownAttribute) {
+			t.Errorf("DecodeValue() error = %v, want %v", err, ErrUnknownAttribute)
+		}
+		got = requiredTarget{}
+		if err := strict.DecodeValue(Map{"id": "u1", "name": "foo"}, &got); err != nil {
+			t.Fatalf("DecodeValue() error = %v", err)
+		}
+		if diff := cmp.Diff(requiredTarget{ID: "u1", Name: "foo"}, got); diff != "" {
+			t.Errorf("DecodeValue() mismatch (-want +got):\n%s", diff)
+		}
+	})
+	t.Run("map-with-sets", func(t *testing.T) {
+		var scanned Map
+		err := scanned.Scan(map[string]interface{}{
+			"id":     "u1",
+			"name":   "foo",
+			"tags":   []string{"a"},
+			"s_tags": []string{"b"},
+			"nums":   []float64{1, 2},
+		})
+		if err != nil {
+			t.Fatalf("Map.Scan() error = %v", err)
+		}
+		converted, err := FromAttributeValue(&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"id":     &types.AttributeValueMemberS{Value: "u1"},
+			"name":   &types.AttributeValueMemberS{Value: "foo"},
+			"tags":   &types.AttributeValueMemberSS{Value: []string{"a"}},
+			"s_tags": &types.AttributeValueMemberSS{Value: []string{"b"}},
+			"nums":   &types.AttributeValueMemberNS{Value: []string{"1", "2"}},
+		}})
+		if err != nil {
+			t.Fatalf("FromAttributeValue() error = %v", err)
+		}
+		want := requiredTarget{ID: "u1", Name: "foo", Tags: []string{"a"}, STags: Set[string]{"b"}, Nums: []int{1, 2}}
+		for name, value := range map[string]interface{}{"scanned": scanned, "converted": converted} {
+			var got requiredTarget
+			if err := strict.DecodeValue(value, &got); err != nil {
+				t.Fatalf("DecodeValue(%s) error = %v", name, err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("DecodeValue(%s) mismatch (-want +got):\n%s", name, diff)
+			}
+		}
+	})
+}
+
+func TestSetDefaultDecoderOptions(t *testing.T) {
+	SetDefaultDecoderOptions(WithDisallowUnknownAttributes())
+	t.Cleanup(func() {
+		SetDefaultDecoderOptions()
+	})
+	var got TypedList[requiredTarget]
+	err := got.Scan([]interface{}{
+		map[string]interface{}{"id": "u1", "name": "foo", "nickname": "baz"},
+	})
+	if !errors.Is(err, ErrUnknownAttribute) {
+		t.Errorf("Scan() error = %v, want %v", err, ErrUnknownAttribute)
+	}
+	if !errors.Is(err, ErrFailedToCast) {
+		t.Errorf("Scan() error = %v, want %v", err, ErrFailedToCast)
+	}
+}
@@ -0,0 +1,170 @@
+package sqldav
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+type Address struct {
+	Zip    string
+	Street *string
+	Floor  int
+}
+
+func TestDocument_Scan(t *testing.T) {
+	type testCase struct {
+		sut           Document[Address]
+		args          interface{}
+		expectedState Document[Address]
+		want          error
+	}
+	tests := map[string]testCase{
+		"happy-path": {
+			args: map[string]interface{}{
+				"zip":    "100-0001",
+				"street": "Chiyoda",
+				"floor":  float64(3),
+			},
+			expectedState: Document[Address]{Data: Address{Zip: "100-0001", Street: aws.String("Chiyoda"), Floor: 3}},
+		},
+		"happy-path/null": {
+			sut:           Document[Address]{Data: Address{Zip: "100-0001"}},
+			args:          nil,
+			expectedState: Document[Address]{},
+		},
+		"unhappy-path/non-map-value": {
+			args: "non-map",
+			want: ErrFailedToCast,
+		},
+		"unhappy-path/incompatible-attribute": {
+			args: map[string]interface{}{"zip": float64(1)},
+			want: ErrNestedStructHasIncompatibleAttributes,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.sut.Scan(tt.args)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Scan() error = %v, want %v", err, tt.want)
+				return
+			}
+			if diff := cmp.Diff(tt.expectedState, tt.sut); diff != "" {
+				t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+				return
+			}
+		})
+	}
+}
+
+func TestDocument_Scan_Pointer(t *testing.T) {
+	type testCase struct {
+		sut           Document[*Address]
+		args          interface{}
+		expectedState Document[*Address]
+		want          error
+	}
+	tests := map[string]testCase{
+		"happy-path": {
+			args:          map[string]interface{}{"zip": "100-0001"},
+			expectedState: Document[*Address]{Data: &Address{Zip: "100-0001"}},
+		},
+		"happy-path/null": {
+			sut:           Document[*Address]{Data: &Address{Zip: "100-0001"}},
+			args:          nil,
+			expectedState: Document[*Address]{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.sut.Scan(tt.args)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Scan() error = %v, want %v", err, tt.want)
+				return
+			}
+			if diff := cmp.Diff(tt.expectedState, tt.sut); diff != "" {
+				t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+				return
+			}
+		})
+	}
+}
+
+func TestDocument_Scan_NonStruct(t *testing.T) {
+	sut := Document[string]{}
+	if err := sut.Scan(map[string]interface{}{}); !errors.Is(err, ErrFailedToCast) {
+		t.Errorf("Scan() error = %v, want %v", err, ErrFailedToCast)
+	}
+	m := Document[map[string]int]{}
+	if err := m.Scan(map[string]interface{}{"a": float64(1)}); !errors.Is(err, ErrFailedToCast) {
+		t.Errorf("Scan() error = %v, want %v", err, ErrFailedToCast)
+	}
+}
+
+func TestDocument_Value(t *testing.T) {
+	type want struct {
+		value interface{}
+		error error
+	}
+	address := &types.AttributeValueMemberM{
+		Value: map[string]types.AttributeValue{
+			"zip":    &types.AttributeValueMemberS{Value: "100-0001"},
+			"street": &types.AttributeValueMemberS{Value: "Chiyoda"},
+			"floor":  &types.AttributeValueMemberN{Value: "3"},
+		},
+	}
+	tests := map[string]struct {
+		sut  func() (interface{}, error)
+		want want
+	}{
+		"happy-path": {
+			sut: func() (interface{}, error) {
+				return Document[Address]{Data: Address{Zip: "100-0001", Street: aws.String("Chiyoda"), Floor: 3}}.Value()
+			},
+			want: want{value: address},
+		},
+		"happy-path/pointer": {
+			sut: func() (interface{}, error) {
+				return Document[*Address]{Data: &Address{Zip: "100-0001", Street: aws.String("Chiyoda"), Floor: 3}}.Value()
+			},
+			want: want{value: address},
+		},
+		"happy-path/nil-pointer": {
+			sut: func() (interface{}, error) {
+				return Document[*Address]{}.Value()
+			},
+			want: want{value: &types.AttributeValueMemberNULL{Value: true}},
+		},
+		"unhappy-path/non-struct": {
+			sut: func() (interface{}, error) {
+				return Document[map[string]int]{Data: map[string]int{"a": 1}}.Value()
+			},
+			want: want{error: ErrDocumentAttributeValueIsIncompatible},
+		},
+		"unhappy-path/pointer-to-non-struct": {
+			sut: func() (interface{}, error) {
+				return Document[*Map]{Data: &Map{"a": "b"}}.Value()
+			},
+			want: want{error: ErrDocumentAttributeValueIsIncompatible},
+		},
+	}
+	opts := []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberN{}),
+		cmp.AllowUnexported(types.AttributeValueMemberM{}),
+		cmp.AllowUnexported(types.AttributeValueMemberNULL{}),
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.sut()
+			if !errors.Is(err, tt.want.error) {
+				t.Errorf("Value() error = %v, want %v", err, tt.want.error)
+			}
+			if diff := cmp.Diff(tt.want.value, got, opts...); diff != "" {
+				t.Errorf("Value() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+package sqldav
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"strconv"
+)
+
+// DriverAdapter normalizes the values from a database/sql driver before they are scanned.
+//
+// The Scan methods accept the values in the shapes of the driver that sqldav has been built for,
+// such as []float64 for NS, [][]byte for BS and map[string]interface{} for M, and any types.AttributeValue.
+// A DriverAdapter converts the values of the other drivers to either of them.
+type DriverAdapter interface {
+	// AdaptDriverValue converts the value from the driver. It is called once with the whole value,
+	// so the nested values must be converted as well.
+	AdaptDriverValue(value interface{}) (interface{}, error)
+}
+
+var (
+	// NativeDriverAdapter passes the values through as they are. This is the default.
+	NativeDriverAdapter DriverAdapter = nativeDriverAdapter{}
+	// DynamoDBJSONDriverAdapter converts the values in the DynamoDB JSON format,
+	// such as map[string]interface{}{"SS": []interface{}{"a"}}, and the JSON string of the whole value, to types.AttributeValue.
+	// The strings nested in the value are kept as they are.
+	// A map without a data type descriptor is taken as an item, whose attributes must be in the DynamoDB JSON format.
+	DynamoDBJSONDriverAdapter DriverAdapter = dynamoDBJSONDriverAdapter{strict: true}
+	// DetectDriverAdapter detects the shape of each value, including the nested ones. It converts the values
+	// in the DynamoDB JSON format like DynamoDBJSONDriverAdapter, and passes the others through like NativeDriverAdapter.
+	// Only the JSON string of the whole value is parsed, so the string attributes that contain JSON are kept as they are.
+	//
+	// Note that a Map whose only attribute is named after a data type descriptor, such as {"S": "small"},
+	// cannot be told from the DynamoDB JSON format. Use DynamoDBJSONDriverAdapter or NativeDriverAdapter if it matters.
+	DetectDriverAdapter DriverAdapter = dynamoDBJSONDriverAdapter{}
+)
+
+// WithDriverAdapter sets the DriverAdapter that normalizes the values from the driver.
+// Set it once per process with SetDefaultDecoderOptions to apply it to the Scan methods of the sqldav types.
+func WithDriverAdapter(a DriverAdapter) DecoderOption {
+	return decoderOptionFunc(func(d *Decoder) {
+		d.driverAdapter = a
+	})
+}
+
+// adapt normalizes the value from the driver with the DriverAdapter of the Decoder.
+func (d *Decoder) adapt(value interface{}) (interface{}, error) {
+	if d.driverAdapter == nil {
+		return value, nil
+	}
+	return d.driverAdapter.AdaptDriverValue(value)
+}
+
+// scanWithDefaultDecoder normalizes the value from the driver and assigns it with the default Decoder.
+// It is the implementation of the Scan methods of the sqldav types.
+func scanWithDefaultDecoder(dd documentDecoder, value interface{}) error {
+	d := defaultDecoder.Load()
+	value, err := d.adapt(value)
+	if err != nil {
+		return err
+	}
+	return dd.decodeWith(d, value)
+}
+
+// nativeDriverAdapter is the DriverAdapter that passes the values through.
+type nativeDriverAdapter struct{}
+
+func (nativeDriverAdapter) AdaptDriverValue(value interface{}) (interface{}, error) {
+	return value, nil
+}
+
+// dynamoDBJSONDriverAdapter is the DriverAdapter for the values in the DynamoDB JSON format.
+// If strict is true, the attributes of the items must be in the format.
+type dynamoDBJSONDriverAdapter struct {
+	strict bool
+}
+
+// AdaptDriverValue converts the value. The JSON text is parsed only for the whole value,
+// so that the string attributes nested in it are kept as they are even if they contain the DynamoDB JSON.
+func (a dynamoDBJSONDriverAdapter) AdaptDriverValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if av, ok, err := dynamoDBJSONOfText([]byte(v)); ok || err != nil {
+			return av, err
+		}
+	case []byte:
+		if av, ok, err := dynamoDBJSONOfText(v); ok || err != nil {
+			return av, err
+		}
+	}
+	return a.adapt(value)
+}
+
+// adapt converts the value nested in the whole value, other than the JSON text.
+func (a dynamoDBJSONDriverAdapter) adapt(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if av, ok, err := dynamoDBJSONOf(v); ok || err != nil {
+			return av, err
+		}
+		return a.adaptItem(v)
+	case []interface{}:
+		lv := make([]interface{}, 0, len(v))
+		for i, e := range v {
+			ae, err := a.adapt(e)
+			if err != nil {
+				return nil, withDecodePath(newDecodeError(emptyInterfaceType, e, err), indexSegment(i), "")
+			}
+			lv = append(lv, ae)
+		}
+		return lv, nil
+	}
+	return value, nil
+}
+
+// adaptItem converts the attributes of the item.
+func (a dynamoDBJSONDriverAdapter) adaptItem(m map[string]interface{}) (interface{}, error) {
+	mv := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		var (
+			av  interface{}
+			err error
+		)
+		if a.strict {
+			av, err = dynamoDBJSONElement(v)
+		} else {
+			av, err = a.adapt(v)
+		}
+		if err != nil {
+			return nil, withDecodePath(newDecodeError(emptyInterfaceType, v, err), k, "")
+		}
+		mv[k] = av
+	}
+	return mv, nil
+}
+
+// dynamoDBJSONOfText converts the JSON text in the DynamoDB JSON format to a types.AttributeValue.
+// Reports false if the text is not in the format.
+func dynamoDBJSONOfText(text []byte) (types.AttributeValue, bool, error) {
+	text = bytes.TrimSpace(text)
+	if len(text) == 0 || text[0] != '{' {
+		return nil, false, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(text))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		return nil, false, nil
+	}
+	return dynamoDBJSONOf(m)
+}
+
+// dynamoDBJSONOf converts the map in the DynamoDB JSON format to a types.AttributeValue.
+// Reports false if the map is not in the format, i.e. it has other than one data type descriptor.
+func dynamoDBJSONOf(m map[string]interface{}) (types.AttributeValue, bool, error) {
+	if len(m) != 1 {
+		return nil, false, nil
+	}
+	for descriptor, v := range m {
+		if !isDataTypeDescriptor(descriptor, v) {
+			return nil, false, nil
+		}
+		av, err := attributeValueOfDescriptor(descriptor, v)
+		if err != nil {
+			return nil, true, errors.Join(ErrFailedToCast, err)
+		}
+		return av, true, nil
+	}
+	return nil, false, nil
+}
+
+// isDataTypeDescriptor reports whether the descriptor is a DynamoDB data type descriptor
+// and the value has the shape of the data type.
+func isDataTypeDescriptor(descriptor string, v interface{}) bool {
+	switch descriptor {
+	case "S":
+		_, ok := v.(string)
+		return ok
+	case "N":
+		switch v.(type) {
+		case string, json.Number, float64:
+			return true
+		}
+	case "B":
+		switch v.(type) {
+		case string, []byte:
+			return true
+		}
+	case "BOOL":
+		_, ok := v.(bool)
+		return ok
+	case "NULL":
+		b, ok := v.(bool)
+		return ok && b
+	case "SS", "NS", "BS", "L":
+		switch v.(type) {
+		case []interface{}, []string, [][]byte, []float64:
+			return true
+		}
+	case "M":
+		_, ok := v.(map[string]interface{})
+		return ok
+	}
+	return false
+}
+
+// attributeValueOfDescriptor converts the value of the data type descriptor to a types.AttributeValue.
+func attributeValueOfDescriptor(descriptor string, v interface{}) (types.AttributeValue, error) {
+	switch descriptor {
+	case "S":
+		return &types.AttributeValueMemberS{Value: v.(string)}, nil
+	case "N":
+		n, err := dynamoDBJSONNumber(v)
+		if err != nil {
+			return nil, err
+		}
+		return &types.AttributeValueMemberN{Value: n}, nil
+	case "B":
+		b, err := dynamoDBJSONBinary(v)
+		if err != nil {
+			return nil, err
+		}
+		return &types.AttributeValueMemberB{Value: b}, nil
+	case "BOOL":
+		return &types.AttributeValueMemberBOOL{Value: v.(bool)}, nil
+	case "NULL":
+		return newNullAttributeValue(), nil
+	case "M":
+		m := v.(map[string]interface{})
+		avm := make(map[string]types.AttributeValue, len(m))
+		for k, e := range m {
+			av, err := dynamoDBJSONElement(e)
+			if err != nil {
+				return nil, withDecodePath(newDecodeError(emptyInterfaceType, e, err), k, "")
+			}
+			avm[k] = av
+		}
+		return &types.AttributeValueMemberM{Value: avm}, nil
+	}
+	elems, _ := elementsOf(v)
+	switch descriptor {
+	case "SS":
+		ss := make([]string, 0, len(elems))
+		for _, e := range elems {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("incompatible SS element %T", e)
+			}
+			ss = append(ss, s)
+		}
+		return &types.AttributeValueMemberSS{Value: ss}, nil
+	case "NS":
+		ns := make([]string, 0, len(elems))
+		for _, e := range elems {
+			n, err := dynamoDBJSONNumber(e)
+			if err != nil {
+				return nil, err
+			}
+			ns = append(ns, n)
+		}
+		return &types.AttributeValueMemberNS{Value: ns}, nil
+	case "BS":
+		bs := make([][]byte, 0, len(elems))
+		for _, e := range elems {
+			b, err := dynamoDBJSONBinary(e)
+			if err != nil {
+				return nil, err
+			}
+			bs = append(bs, b)
+		}
+		return &types.AttributeValueMemberBS{Value: bs}, nil
+	}
+	avl := make([]types.AttributeValue, 0, len(elems))
+	for i, e := range elems {
+		av, err := dynamoDBJSONElement(e)
+		if err != nil {
+			return nil, withDecodePath(newDecodeError(emptyInterfaceType, e, err), indexSegment(i), "")
+		}
+		avl = append(avl, av)
+	}
+	return &types.AttributeValueMemberL{Value: avl}, nil
+}
+
+// dynamoDBJSONElement converts the element of L or M in the DynamoDB JSON format to a types.AttributeValue.
+func dynamoDBJSONElement(v interface{}) (types.AttributeValue, error) {
+	if av, ok := v.(types.AttributeValue); ok {
+		return av, nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.Join(ErrFailedToCast, fmt.Errorf("incompatible DynamoDB JSON and %T", v))
+	}
+	av, ok, err := dynamoDBJSONOf(m)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Join(ErrFailedToCast, fmt.Errorf("no data type descriptor in %v", m))
+	}
+	return av, nil
+}
+
+// dynamoDBJSONNumber returns the number of N or the element of NS in the DynamoDB JSON format.
+func dynamoDBJSONNumber(v interface{}) (string, error) {
+	var n Number
+	switch v := v.(type) {
+	case string:
+		n = Number(v)
+	case json.Number:
+		n = Number(v)
+	case float64:
+		n = Number(strconv.FormatFloat(v, 'g', -1, 64))
+	default:
+		return "", fmt.Errorf("incompatible number %T", v)
+	}
+	if !n.IsValid() {
+		return "", errors.Join(ErrInvalidNumber, fmt.Errorf("%q is not a number", n))
+	}
+	return string(n), nil
+}
+
+// dynamoDBJSONBinary returns the binary of B or the element of BS in the DynamoDB JSON format.
+// Strings are decoded from base64 as in the DynamoDB JSON format.
+func dynamoDBJSONBinary(v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return base64.StdEncoding.DecodeString(v)
+	}
+	return nil, fmt.Errorf("incompatible binary %T", v)
+}
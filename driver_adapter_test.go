@@ -0,0 +1,191 @@
+package sqldav
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+// setDriverAdapter sets the DriverAdapter of the default Decoder until the end of the test.
+func setDriverAdapter(t *testing.T, a DriverAdapter) {
+	t.Helper()
+	SetDefaultDecoderOptions(WithDriverAdapter(a))
+	t.Cleanup(func() {
+		SetDefaultDecoderOptions()
+	})
+}
+
+func TestDynamoDBJSONDriverAdapter(t *testing.T) {
+	type test struct {
+		args    interface{}
+		want    interface{}
+		wantErr error
+	}
+	tests := map[string]test{
+		"string-set": {
+			args: map[string]interface{}{"SS": []interface{}{"a", "b"}},
+			want: &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+		},
+		"number-set": {
+			args: map[string]interface{}{"NS": []interface{}{"1", 2.5}},
+			want: &types.AttributeValueMemberNS{Value: []string{"1", "2.5"}},
+		},
+		"binary-set": {
+			args: map[string]interface{}{"BS": []interface{}{"YQ=="}},
+			want: &types.AttributeValueMemberBS{Value: [][]byte{[]byte("a")}},
+		},
+		"nested": {
+			args: map[string]interface{}{"M": map[string]interface{}{
+				"l": map[string]interface{}{"L": []interface{}{
+					map[string]interface{}{"N": "1"},
+					map[string]interface{}{"NULL": true},
+					map[string]interface{}{"BOOL": false},
+				}},
+			}},
+			want: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"l": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+					&types.AttributeValueMemberN{Value: "1"},
+					&types.AttributeValueMemberNULL{Value: true},
+					&types.AttributeValueMemberBOOL{Value: false},
+				}},
+			}},
+		},
+		"json-string": {
+			args: `{"NS": ["12345678901234567890"]}`,
+			want: &types.AttributeValueMemberNS{Value: []string{"12345678901234567890"}},
+		},
+		"item": {
+			args: map[string]interface{}{"id": map[string]interface{}{"S": "u1"}, "age": map[string]interface{}{"N": "30"}},
+			want: map[string]interface{}{
+				"id":  &types.AttributeValueMemberS{Value: "u1"},
+				"age": &types.AttributeValueMemberN{Value: "30"},
+			},
+		},
+		"plain-string": {
+			args: "a",
+			want: "a",
+		},
+		"item-with-plain-attribute": {
+			args:    map[string]interface{}{"id": "u1", "age": map[string]interface{}{"N": "30"}},
+			wantErr: ErrFailedToCast,
+		},
+		"invalid-number": {
+			args:    map[string]interface{}{"N": "one"},
+			wantErr: ErrInvalidNumber,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := DynamoDBJSONDriverAdapter.AdaptDriverValue(tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AdaptDriverValue() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got, attributeValueCmpOptions()...); diff != "" {
+				t.Errorf("AdaptDriverValue() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDetectDriverAdapter(t *testing.T) {
+	args := map[string]interface{}{
+		"id":   "u1",
+		"tags": map[string]interface{}{"SS": []interface{}{"a"}},
+		"l":    []interface{}{map[string]interface{}{"N": "1"}, "b"},
+		"m":    map[string]interface{}{"k": "v", "n": 1.5},
+	}
+	want := map[string]interface{}{
+		"id":   "u1",
+		"tags": &types.AttributeValueMemberSS{Value: []string{"a"}},
+		"l":    []interface{}{&types.AttributeValueMemberN{Value: "1"}, "b"},
+		"m":    map[string]interface{}{"k": "v", "n": 1.5},
+	}
+	got, err := DetectDriverAdapter.AdaptDriverValue(args)
+	if err != nil {
+		t.Fatalf("AdaptDriverValue() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got, attributeValueCmpOptions()...); diff != "" {
+		t.Errorf("AdaptDriverValue() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDetectDriverAdapter_NestedJSONString(t *testing.T) {
+	setDriverAdapter(t, DetectDriverAdapter)
+
+	var m Map
+	if err := m.Scan(map[string]interface{}{"note": `{"S":"hi"}`}); err != nil {
+		t.Fatalf("Map.Scan() error = %v", err)
+	}
+	if diff := cmp.Diff(Map{"note": `{"S":"hi"}`}, m); diff != "" {
+		t.Errorf("Map.Scan() mismatch (-want +got):\n%s", diff)
+	}
+
+	var l List
+	if err := l.Scan([]interface{}{`{"BOOL":true}`, []byte(`{"N":"1"}`)}); err != nil {
+		t.Fatalf("List.Scan() error = %v", err)
+	}
+	if diff := cmp.Diff(List{`{"BOOL":true}`, []byte(`{"N":"1"}`)}, l); diff != "" {
+		t.Errorf("List.Scan() mismatch (-want +got):\n%s", diff)
+	}
+
+	// the JSON text of the whole value is still parsed.
+	var s Set[string]
+	if err := s.Scan(`{"SS":["a"]}`); err != nil {
+		t.Fatalf("Set.Scan() error = %v", err)
+	}
+	if diff := cmp.Diff(Set[string]{"a"}, s); diff != "" {
+		t.Errorf("Set.Scan() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWithDriverAdapter_Scan(t *testing.T) {
+	setDriverAdapter(t, DetectDriverAdapter)
+
+	var s Set[int]
+	if err := s.Scan(map[string]interface{}{"NS": []interface{}{"1", "2"}}); err != nil {
+		t.Fatalf("Set.Scan() error = %v", err)
+	}
+	if diff := cmp.Diff(Set[int]{1, 2}, s); diff != "" {
+		t.Errorf("Set.Scan() mismatch (-want +got):\n%s", diff)
+	}
+
+	var m Map
+	if err := m.Scan(`{"M": {"tags": {"SS": ["a"]}, "n": {"N": "1"}}}`); err != nil {
+		t.Fatalf("Map.Scan() error = %v", err)
+	}
+	if diff := cmp.Diff(Map{"tags": Set[string]{"a"}, "n": float64(1)}, m); diff != "" {
+		t.Errorf("Map.Scan() mismatch (-want +got):\n%s", diff)
+	}
+
+	var l TypedList[LocaleSetting]
+	if err := l.Scan(map[string]interface{}{"L": []interface{}{
+		map[string]interface{}{"M": map[string]interface{}{"title": map[string]interface{}{"S": "foo"}}},
+	}}); err != nil {
+		t.Fatalf("TypedList.Scan() error = %v", err)
+	}
+	if diff := cmp.Diff(TypedList[LocaleSetting]{{Title: "foo"}}, l); diff != "" {
+		t.Errorf("TypedList.Scan() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSet_Scan_InterfaceSlice(t *testing.T) {
+	var ss Set[string]
+	if err := ss.Scan([]interface{}{"a", "b"}); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if diff := cmp.Diff(Set[string]{"a", "b"}, ss); diff != "" {
+		t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+	}
+	var is Set[int8]
+	if err := is.Scan([]interface{}{float64(1), "2"}); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if diff := cmp.Diff(Set[int8]{1, 2}, is); diff != "" {
+		t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+	}
+	var bs Set[[]byte]
+	if err := bs.Scan([]interface{}{"a"}); !errors.Is(err, ErrValueIsIncompatibleOfBinarySlice) {
+		t.Errorf("Scan() error = %v, want %v", err, ErrValueIsIncompatibleOfBinarySlice)
+	}
+}
@@ -0,0 +1,496 @@
+package sqldav
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/miyamo2/sqldav/internal/structtag"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// ErrIncompatibleTagOptions occurs when the options of the struct tag cannot be applied to the field.
+var ErrIncompatibleTagOptions = errors.New("incompatible tag options")
+
+// Encoder converts Go values to types.AttributeValue.
+type Encoder struct {
+	fieldConfig
+	omitNullAttributes bool
+	emptySetAsNull     bool
+}
+
+// EncoderOption configures the Encoder.
+type EncoderOption interface {
+	applyToEncoder(e *Encoder)
+}
+
+// encoderOptionFunc is an EncoderOption that configures the Encoder by function.
+type encoderOptionFunc func(e *Encoder)
+
+func (f encoderOptionFunc) applyToEncoder(e *Encoder) {
+	f(e)
+}
+
+// WithOmitNullAttributes omits the struct fields that are converted to NULL, instead of writing NULL.
+func WithOmitNullAttributes() EncoderOption {
+	return encoderOptionFunc(func(e *Encoder) {
+		e.omitNullAttributes = true
+	})
+}
+
+// WithEmptySetAsNull converts the empty Set to NULL, instead of returning ErrSetIsEmpty.
+func WithEmptySetAsNull() EncoderOption {
+	return encoderOptionFunc(func(e *Encoder) {
+		e.emptySetAsNull = true
+	})
+}
+
+// NewEncoder returns a new Encoder.
+func NewEncoder(opts ...EncoderOption) *Encoder {
+	e := &Encoder{fieldConfig: newFieldConfig()}
+	for _, opt := range opts {
+		opt.applyToEncoder(e)
+	}
+	return e
+}
+
+// defaultEncoder is the Encoder used by Marshal and the sqldav types.
+var defaultEncoder = NewEncoder()
+
+// Marshal converts the Go value to a types.AttributeValue with the default Encoder.
+func Marshal(v interface{}) (types.AttributeValue, error) {
+	return defaultEncoder.Encode(v)
+}
+
+// Encode converts the Go value to a types.AttributeValue.
+//
+// Structs are converted to a map whose keys are resolved by the struct tags and the NamingStrategy.
+func (e *Encoder) Encode(v interface{}) (types.AttributeValue, error) {
+	return e.encode(v)
+}
+
+// documentEncoder is implemented by the sqldav types to convert themselves with the Encoder.
+type documentEncoder interface {
+	encodeWith(e *Encoder) (types.AttributeValue, error)
+}
+
+// encode converts the value to a types.AttributeValue
+//
+// The error is an *EncodeError, or wraps one.
+func (e *Encoder) encode(value interface{}) (types.AttributeValue, error) {
+	av, err := e.encodeValue(value)
+	if err != nil {
+		return nil, newEncodeError(reflect.TypeOf(value), err)
+	}
+	return av, nil
+}
+
+// encodeValue converts the value to a types.AttributeValue
+func (e *Encoder) encodeValue(value interface{}) (types.AttributeValue, error) {
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return newNullAttributeValue(), nil
+	}
+	if value != nil {
+		if c := e.converterOf(reflect.TypeOf(value)); c.encode != nil {
+			return c.encode(reflect.ValueOf(value))
+		}
+	}
+	switch value := value.(type) {
+	case nil:
+		return newNullAttributeValue(), nil
+	case types.AttributeValue:
+		return value, nil
+	case AttributeValueMarshaler:
+		return value.MarshalAttributeValue()
+	case documentEncoder:
+		return value.encodeWith(e)
+	case Number:
+		if !value.IsValid() {
+			return nil, errors.Join(ErrInvalidNumber, fmt.Errorf("%q is not a number", value))
+		}
+		return &types.AttributeValueMemberN{Value: string(value)}, nil
+	case driver.Valuer:
+		v, err := value.Value()
+		if err != nil {
+			return nil, err
+		}
+		if av, ok := v.(types.AttributeValue); ok {
+			return av, nil
+		}
+		return attributevalue.Marshal(v)
+	case attributevalue.Marshaler:
+		return value.MarshalDynamoDBAttributeValue()
+	}
+	return e.encodeReflectValue(reflect.ValueOf(value))
+}
+
+// encodeReflect converts the reflect.Value to a types.AttributeValue with the encoderFunc compiled for its type.
+//
+// The error is an *EncodeError, or wraps one.
+func (e *Encoder) encodeReflect(rv reflect.Value) (types.AttributeValue, error) {
+	if c := e.converterOf(rv.Type()); c.encode != nil {
+		av, err := c.encode(rv)
+		if err != nil {
+			return nil, newEncodeError(rv.Type(), err)
+		}
+		return av, nil
+	}
+	return encoderFuncOf(rv.Type())(e, rv)
+}
+
+// encoderFunc converts the reflect.Value of a specific type to a types.AttributeValue.
+type encoderFunc func(e *Encoder, rv reflect.Value) (types.AttributeValue, error)
+
+// encoderFuncs caches the encoderFunc per reflect.Type.
+var encoderFuncs sync.Map
+
+// encoderFuncOf returns the encoderFunc for the type. It is compiled once per type and cached.
+func encoderFuncOf(rt reflect.Type) encoderFunc {
+	if f, ok := encoderFuncs.Load(rt); ok {
+		return f.(encoderFunc)
+	}
+	f, _ := encoderFuncs.LoadOrStore(rt, compileEncoderFunc(rt))
+	return f.(encoderFunc)
+}
+
+// compileEncoderFunc returns the encoderFunc for the type.
+//
+// Scalars and structs are converted without boxing the value into interface{}.
+// The types that convert themselves fall back to encode.
+func compileEncoderFunc(rt reflect.Type) encoderFunc {
+	if rt == numberType || rt.Implements(attributeValueType) || implementsMarshaler(rt) ||
+		rt.Implements(documentEncoderType) || rt.Implements(valuerType) {
+		return encodeInterface
+	}
+	if implementsMarshaler(reflect.PointerTo(rt)) {
+		return func(e *Encoder, rv reflect.Value) (types.AttributeValue, error) {
+			if !rv.CanAddr() {
+				pv := reflect.New(rt)
+				pv.Elem().Set(rv)
+				return e.encode(pv.Interface())
+			}
+			return e.encode(rv.Addr().Interface())
+		}
+	}
+	switch rt.Kind() {
+	case reflect.String:
+		return func(_ *Encoder, rv reflect.Value) (types.AttributeValue, error) {
+			return &types.AttributeValueMemberS{Value: rv.String()}, nil
+		}
+	case reflect.Bool:
+		return func(_ *Encoder, rv reflect.Value) (types.AttributeValue, error) {
+			return &types.AttributeValueMemberBOOL{Value: rv.Bool()}, nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(_ *Encoder, rv reflect.Value) (types.AttributeValue, error) {
+			return &types.AttributeValueMemberN{Value: strconv.FormatInt(rv.Int(), 10)}, nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(_ *Encoder, rv reflect.Value) (types.AttributeValue, error) {
+			return &types.AttributeValueMemberN{Value: strconv.FormatUint(rv.Uint(), 10)}, nil
+		}
+	case reflect.Float32, reflect.Float64:
+		bits := rt.Bits()
+		return func(_ *Encoder, rv reflect.Value) (types.AttributeValue, error) {
+			return &types.AttributeValueMemberN{Value: strconv.FormatFloat(rv.Float(), 'f', -1, bits)}, nil
+		}
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return func(e *Encoder, rv reflect.Value) (types.AttributeValue, error) {
+			av, err := e.encodeReflectValue(rv)
+			if err != nil {
+				return nil, newEncodeError(rt, err)
+			}
+			return av, nil
+		}
+	}
+	return encodeInterface
+}
+
+// encodeInterface is the encoderFunc that converts the value with encode.
+func encodeInterface(e *Encoder, rv reflect.Value) (types.AttributeValue, error) {
+	return e.encode(rv.Interface())
+}
+
+// encodeReflectValue converts the reflect.Value to a types.AttributeValue
+func (e *Encoder) encodeReflectValue(rv reflect.Value) (types.AttributeValue, error) {
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return newNullAttributeValue(), nil
+		}
+		return e.encode(rv.Elem().Interface())
+	case reflect.Struct:
+		return e.encodeStruct(rv)
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if rv.IsNil() {
+			return newNullAttributeValue(), nil
+		}
+		avm := make(map[string]types.AttributeValue, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			if state, ok := optionalStateOf(iter.Value()); ok && state == OptionalMissing {
+				continue
+			}
+			av, err := e.encodeReflect(iter.Value())
+			if err != nil {
+				return nil, withEncodePath(err, iter.Key().String(), "")
+			}
+			avm[iter.Key().String()] = av
+		}
+		return &types.AttributeValueMemberM{Value: avm}, nil
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return newNullAttributeValue(), nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return &types.AttributeValueMemberB{Value: b}, nil
+		}
+		avl := make([]types.AttributeValue, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			av, err := e.encodeReflect(rv.Index(i))
+			if err != nil {
+				return nil, withEncodePath(err, indexSegment(i), "")
+			}
+			avl = append(avl, av)
+		}
+		return &types.AttributeValueMemberL{Value: avl}, nil
+	case reflect.String:
+		return &types.AttributeValueMemberS{Value: rv.String()}, nil
+	case reflect.Bool:
+		return &types.AttributeValueMemberBOOL{Value: rv.Bool()}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &types.AttributeValueMemberN{Value: strconv.FormatInt(rv.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &types.AttributeValueMemberN{Value: strconv.FormatUint(rv.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return &types.AttributeValueMemberN{Value: strconv.FormatFloat(rv.Float(), 'f', -1, rv.Type().Bits())}, nil
+	}
+	return attributevalue.Marshal(rv.Interface())
+}
+
+// encodeStruct converts the struct to a *types.AttributeValueMemberM
+func (e *Encoder) encodeStruct(rv reflect.Value) (types.AttributeValue, error) {
+	fields := e.structFieldsOf(rv.Type())
+	avm := make(map[string]types.AttributeValue, len(fields))
+	for _, f := range fields {
+		fv, ok := fieldByIndex(rv, f.index)
+		if !ok {
+			continue
+		}
+		state, optional := optionalStateOf(fv)
+		if optional && state == OptionalMissing {
+			continue
+		}
+		av, err := e.encodeField(fv, f.tag)
+		if err != nil {
+			return nil, withEncodePath(err, f.tag.Name, f.field)
+		}
+		if av == nil {
+			continue
+		}
+		if _, ok := av.(*types.AttributeValueMemberNULL); ok && e.omitNullAttributes && !optional {
+			continue
+		}
+		avm[f.tag.Name] = av
+	}
+	return &types.AttributeValueMemberM{Value: avm}, nil
+}
+
+// fieldByIndex returns the nested field of the struct.
+// It reports false if the field is promoted through a nil pointer to an embedded struct.
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// encodeField converts the value to a types.AttributeValue with the options of the structtag.Tag.
+//
+// Returns nil if the value is omitted.
+func (e *Encoder) encodeField(rv reflect.Value, ft structtag.Tag) (types.AttributeValue, error) {
+	av, err := e.encodeTaggedValue(rv, ft)
+	if err != nil {
+		return nil, newEncodeError(rv.Type(), err)
+	}
+	return av, nil
+}
+
+// encodeTaggedValue converts the value to a types.AttributeValue with the options of the structtag.Tag.
+func (e *Encoder) encodeTaggedValue(rv reflect.Value, ft structtag.Tag) (types.AttributeValue, error) {
+	if isEmptyValue(rv) {
+		switch {
+		case ft.OmitEmpty && ft.NullEmpty:
+			return nil, errors.Join(ErrIncompatibleTagOptions, errors.New("omitempty and nullempty"))
+		case ft.OmitEmpty:
+			return nil, nil
+		case ft.NullEmpty:
+			return newNullAttributeValue(), nil
+		}
+	}
+	if ft.AsSet() {
+		return e.encodeTaggedSet(rv, ft)
+	}
+	if (ft.OmitEmptyElem || ft.NullEmptyElem) && !e.hasCustomEncoding(rv) {
+		return e.encodeTaggedElems(rv, ft)
+	}
+	return e.encodeReflect(rv)
+}
+
+// encodeTaggedSet converts the slice or the array to a set specified by the stringset, numberset or binaryset option.
+//
+// The empty slice is converted to NULL.
+func (e *Encoder) encodeTaggedSet(rv reflect.Value, ft structtag.Tag) (types.AttributeValue, error) {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return newNullAttributeValue(), nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, errors.Join(ErrIncompatibleTagOptions, fmt.Errorf("set option for %v", rv.Type()))
+	}
+	if rv.Len() == 0 {
+		return newNullAttributeValue(), nil
+	}
+	var (
+		ss []string
+		ns []string
+		bs [][]byte
+	)
+	for i := 0; i < rv.Len(); i++ {
+		av, err := e.encodeField(rv.Index(i), ft.ElemTag())
+		if err != nil {
+			return nil, withEncodePath(err, indexSegment(i), "")
+		}
+		switch av := av.(type) {
+		case nil:
+			continue
+		case *types.AttributeValueMemberS:
+			if ft.AsStringSet {
+				ss = append(ss, av.Value)
+				continue
+			}
+		case *types.AttributeValueMemberN:
+			if ft.AsNumberSet {
+				ns = append(ns, av.Value)
+				continue
+			}
+		case *types.AttributeValueMemberB:
+			if ft.AsBinarySet {
+				bs = append(bs, av.Value)
+				continue
+			}
+		}
+		return nil, errors.Join(ErrDocumentAttributeValueIsIncompatible, fmt.Errorf("incompatible set element %v", rv.Index(i).Type()))
+	}
+	switch {
+	case ft.AsStringSet:
+		return &types.AttributeValueMemberSS{Value: ss}, nil
+	case ft.AsNumberSet:
+		return &types.AttributeValueMemberNS{Value: ns}, nil
+	}
+	return &types.AttributeValueMemberBS{Value: bs}, nil
+}
+
+// encodeTaggedElems converts the elements of the list or the map with the omitemptyelem and nullemptyelem options.
+func (e *Encoder) encodeTaggedElems(rv reflect.Value, ft structtag.Tag) (types.AttributeValue, error) {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return newNullAttributeValue(), nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 || (rv.Kind() == reflect.Slice && rv.IsNil()) {
+			break
+		}
+		avl := make([]types.AttributeValue, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			av, err := e.encodeField(rv.Index(i), ft.ElemTag())
+			if err != nil {
+				return nil, withEncodePath(err, indexSegment(i), "")
+			}
+			if av != nil {
+				avl = append(avl, av)
+			}
+		}
+		return &types.AttributeValueMemberL{Value: avl}, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String || rv.IsNil() {
+			break
+		}
+		avm := make(map[string]types.AttributeValue, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			if state, ok := optionalStateOf(iter.Value()); ok && state == OptionalMissing {
+				continue
+			}
+			av, err := e.encodeField(iter.Value(), ft.ElemTag())
+			if err != nil {
+				return nil, withEncodePath(err, iter.Key().String(), "")
+			}
+			if av != nil {
+				avm[iter.Key().String()] = av
+			}
+		}
+		return &types.AttributeValueMemberM{Value: avm}, nil
+	}
+	return e.encode(rv.Interface())
+}
+
+// hasCustomEncoding reports whether the value is converted by itself or by a converter rather than by its kind.
+func (e *Encoder) hasCustomEncoding(rv reflect.Value) bool {
+	if e.converterOf(rv.Type()).encode != nil {
+		return true
+	}
+	if implementsMarshaler(reflect.PointerTo(rv.Type())) {
+		return true
+	}
+	switch rv.Interface().(type) {
+	case types.AttributeValue, AttributeValueMarshaler, documentEncoder, attributevalue.Marshaler, Number, driver.Valuer:
+		return true
+	}
+	return false
+}
+
+// isEmptyValue reports whether the value is empty in the sense of the omitempty and nullempty options.
+func isEmptyValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Array, reflect.String:
+		return rv.Len() == 0
+	case reflect.Map, reflect.Slice, reflect.Interface, reflect.Pointer:
+		return rv.IsNil()
+	case reflect.Bool:
+		return !rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	}
+	return false
+}
+
+// newNullAttributeValue returns a new *types.AttributeValueMemberNULL
+func newNullAttributeValue() *types.AttributeValueMemberNULL {
+	return &types.AttributeValueMemberNULL{Value: true}
+}
@@ -0,0 +1,281 @@
+package sqldav
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"strings"
+	"testing"
+)
+
+type encodeTarget struct {
+	UserID    string
+	Nickname  *string `db:"nick"`
+	Age       int     `gorm:"column:age_in_years"`
+	Email     string  `xorm:"varchar(255) 'mail_address'"`
+	Roles     Set[string]
+	Tags      []string
+	Ratio     float64
+	Active    bool
+	unexposed string
+}
+
+func TestMarshal(t *testing.T) {
+	type want struct {
+		av  types.AttributeValue
+		err error
+	}
+	type test struct {
+		sut  *Encoder
+		args interface{}
+		want want
+	}
+	tests := map[string]test{
+		"happy-path/struct": {
+			sut: NewEncoder(),
+			args: encodeTarget{
+				UserID:    "u1",
+				Age:       20,
+				Email:     "foo@example.com",
+				Roles:     Set[string]{"b", "a"},
+				Tags:      []string{"x"},
+				Ratio:     0.5,
+				Active:    true,
+				unexposed: "ignored",
+			},
+			want: want{
+				av: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"user_id":      &types.AttributeValueMemberS{Value: "u1"},
+					"nick":         &types.AttributeValueMemberNULL{Value: true},
+					"age_in_years": &types.AttributeValueMemberN{Value: "20"},
+					"mail_address": &types.AttributeValueMemberS{Value: "foo@example.com"},
+					"roles":        &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+					"tags": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+						&types.AttributeValueMemberS{Value: "x"},
+					}},
+					"ratio":  &types.AttributeValueMemberN{Value: "0.5"},
+					"active": &types.AttributeValueMemberBOOL{Value: true},
+				}},
+			},
+		},
+		"happy-path/omit-null-attributes": {
+			sut:  NewEncoder(WithOmitNullAttributes()),
+			args: struct{ Name *string }{},
+			want: want{
+				av: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{}},
+			},
+		},
+		"happy-path/empty-set-as-null": {
+			sut:  NewEncoder(WithEmptySetAsNull()),
+			args: struct{ Roles Set[string] }{},
+			want: want{
+				av: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"roles": &types.AttributeValueMemberNULL{Value: true},
+				}},
+			},
+		},
+		"happy-path/tag-keys": {
+			sut: NewEncoder(WithTagKeys("json", "db")),
+			args: struct {
+				Name string `json:"full_name,omitempty" db:"name"`
+			}{Name: "foo"},
+			want: want{
+				av: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"full_name": &types.AttributeValueMemberS{Value: "foo"},
+				}},
+			},
+		},
+		"happy-path/naming-strategy": {
+			sut:  NewEncoder(WithNamingStrategy(NamingStrategyFunc(strings.ToUpper))),
+			args: struct{ Name string }{Name: "foo"},
+			want: want{
+				av: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"NAME": &types.AttributeValueMemberS{Value: "foo"},
+				}},
+			},
+		},
+		"happy-path/map": {
+			sut:  NewEncoder(),
+			args: map[string]int{"a": 1},
+			want: want{
+				av: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"a": &types.AttributeValueMemberN{Value: "1"},
+				}},
+			},
+		},
+		"happy-path/nil": {
+			sut:  NewEncoder(),
+			args: nil,
+			want: want{
+				av: &types.AttributeValueMemberNULL{Value: true},
+			},
+		},
+		"unhappy-path/empty-set": {
+			sut:  NewEncoder(),
+			args: struct{ Roles Set[string] }{},
+			want: want{
+				err: ErrSetIsEmpty,
+			},
+		},
+	}
+	opts := []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberSS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberN{}),
+		cmp.AllowUnexported(types.AttributeValueMemberL{}),
+		cmp.AllowUnexported(types.AttributeValueMemberM{}),
+		cmp.AllowUnexported(types.AttributeValueMemberBOOL{}),
+		cmp.AllowUnexported(types.AttributeValueMemberNULL{}),
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.sut.Encode(tt.args)
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Encode() error = %v, want %v", err, tt.want.err)
+				return
+			}
+			if diff := cmp.Diff(tt.want.av, got, opts...); diff != "" {
+				t.Errorf("Encode() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMarshal_DefaultEncoder(t *testing.T) {
+	got, err := Marshal(TypedList[int]{1, 2})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := &types.AttributeValueMemberL{Value: []types.AttributeValue{
+		&types.AttributeValueMemberN{Value: "1"},
+		&types.AttributeValueMemberN{Value: "2"},
+	}}
+	opts := []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberN{}),
+		cmp.AllowUnexported(types.AttributeValueMemberL{}),
+	}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Errorf("Marshal() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type taggedTarget struct {
+	ID        string            `dynamodbav:"id"`
+	Name      string            `dynamodbav:"name,omitempty"`
+	Nickname  string            `dynamodbav:"nickname,nullempty"`
+	Age       int               `json:"age,omitempty"`
+	Secret    string            `dynamodbav:"-"`
+	Internal  string            `json:"-"`
+	Tags      []string          `dynamodbav:"tags,stringset"`
+	Scores    []int             `dynamodbav:"scores,numberset"`
+	Blobs     [][]byte          `dynamodbav:"blobs,binaryset"`
+	Empty     []string          `dynamodbav:"empty,stringset"`
+	Notes     []string          `dynamodbav:"notes,omitemptyelem"`
+	Labels    map[string]string `dynamodbav:"labels,nullemptyelem"`
+	Untouched *string           `dynamodbav:"untouched,omitempty"`
+}
+
+func TestMarshal_TagOptions(t *testing.T) {
+	v := taggedTarget{
+		ID:       "u1",
+		Secret:   "secret",
+		Internal: "internal",
+		Tags:     []string{"a", "b"},
+		Scores:   []int{1, 2},
+		Blobs:    [][]byte{[]byte("x")},
+		Empty:    []string{},
+		Notes:    []string{"a", "", "b"},
+		Labels:   map[string]string{"a": "", "b": "c"},
+	}
+	want := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"id":       &types.AttributeValueMemberS{Value: "u1"},
+		"nickname": &types.AttributeValueMemberNULL{Value: true},
+		"tags":     &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+		"scores":   &types.AttributeValueMemberNS{Value: []string{"1", "2"}},
+		"blobs":    &types.AttributeValueMemberBS{Value: [][]byte{[]byte("x")}},
+		"empty":    &types.AttributeValueMemberNULL{Value: true},
+		"notes": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberS{Value: "a"},
+			&types.AttributeValueMemberS{Value: "b"},
+		}},
+		"labels": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"a": &types.AttributeValueMemberNULL{Value: true},
+			"b": &types.AttributeValueMemberS{Value: "c"},
+		}},
+	}}
+	opts := []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberSS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberN{}),
+		cmp.AllowUnexported(types.AttributeValueMemberNS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberBS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberL{}),
+		cmp.AllowUnexported(types.AttributeValueMemberM{}),
+		cmp.AllowUnexported(types.AttributeValueMemberNULL{}),
+	}
+	got, err := Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Errorf("Marshal() mismatch (-want +got):\n%s", diff)
+	}
+	// attributevalue reads only the dynamodbav tag and uses the field name as it is.
+	got, err = NewEncoder(WithTagKeys("dynamodbav"), WithNamingStrategy(Identity)).Encode(v)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	sdk, err := attributevalue.Marshal(v)
+	if err != nil {
+		t.Fatalf("attributevalue.Marshal() error = %v", err)
+	}
+	if diff := cmp.Diff(sdk, got, opts...); diff != "" {
+		t.Errorf("Marshal() differs from attributevalue.Marshal() (-sdk +got):\n%s", diff)
+	}
+}
+
+func TestMarshal_TagOptions_DBAndDynamoDBAV(t *testing.T) {
+	type user struct {
+		ID       string   `db:"id" dynamodbav:"id"`
+		Nickname string   `db:"nick" dynamodbav:"nick,omitempty"`
+		Tags     []string `db:"tags" dynamodbav:"tags,stringset"`
+	}
+	v := user{ID: "u1", Tags: []string{"a"}}
+	want := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"id":   &types.AttributeValueMemberS{Value: "u1"},
+		"tags": &types.AttributeValueMemberSS{Value: []string{"a"}},
+	}}
+	got, err := Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got, attributeValueCmpOptions()...); diff != "" {
+		t.Errorf("Marshal() mismatch (-want +got):\n%s", diff)
+	}
+	sdk, err := attributevalue.Marshal(v)
+	if err != nil {
+		t.Fatalf("attributevalue.Marshal() error = %v", err)
+	}
+	if diff := cmp.Diff(sdk, got, attributeValueCmpOptions()...); diff != "" {
+		t.Errorf("Marshal() differs from attributevalue.Marshal() (-sdk +got):\n%s", diff)
+	}
+}
+
+func TestMarshal_TagOptions_Incompatible(t *testing.T) {
+	tests := map[string]interface{}{
+		"omitempty-and-nullempty": struct {
+			Name string `dynamodbav:"name,omitempty,nullempty"`
+		}{},
+		"set-option-for-scalar": struct {
+			Name string `dynamodbav:"name,stringset"`
+		}{Name: "foo"},
+	}
+	for name, v := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Marshal(v); !errors.Is(err, ErrIncompatibleTagOptions) {
+				t.Errorf("Marshal() error = %v, want %v", err, ErrIncompatibleTagOptions)
+			}
+		})
+	}
+}
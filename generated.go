@@ -0,0 +1,91 @@
+package sqldav
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/miyamo2/sqldav/internal/genhook"
+	"github.com/miyamo2/sqldav/internal/structtag"
+	"reflect"
+)
+
+func init() {
+	genhook.Default = genRuntime{}
+}
+
+// genRuntime is the genhook.Runtime that the genruntime package calls for the Scan and Value methods
+// generated by sqldav-gen. It converts the values with the default Encoder and Decoder, like the reflective path.
+type genRuntime struct{}
+
+func (genRuntime) AttributesOf(value interface{}) (map[string]interface{}, error) {
+	value, err := defaultDecoder.Load().adapt(value)
+	if err != nil {
+		return nil, err
+	}
+	if isNull(value) {
+		return nil, nil
+	}
+	mv, ok := mapOf(value)
+	if !ok {
+		return nil, errors.Join(ErrFailedToCast, fmt.Errorf("incompatible map and %T", value))
+	}
+	return mv, nil
+}
+
+func (genRuntime) StringOf(value interface{}) (string, bool) {
+	return stringOf(value)
+}
+
+func (genRuntime) BoolOf(value interface{}) (bool, bool) {
+	return boolOf(value)
+}
+
+// DecodeField decodes the attribute without the DriverAdapter, since AttributesOf has normalized the whole value.
+func (genRuntime) DecodeField(dst interface{}, value interface{}) error {
+	rv := reflect.ValueOf(dst).Elem()
+	return defaultDecoder.Load().decode(rv.Type(), rv, value)
+}
+
+func (genRuntime) DecodeFieldError(err error, name, field string) error {
+	return withDecodePath(err, name, field)
+}
+
+func (genRuntime) MissingAttributeError(dst interface{}, structType, name, field string) error {
+	rt := reflect.TypeOf(dst).Elem()
+	return &DecodeError{
+		Path:     name,
+		Type:     rt,
+		Field:    field,
+		Expected: expectedAttributeType(rt),
+		Err:      errors.Join(ErrMissingAttribute, fmt.Errorf("%q is required by %s", name, structType)),
+	}
+}
+
+func (genRuntime) DisallowsUnknownAttributes() bool {
+	return defaultDecoder.Load().disallowUnknownAttributes
+}
+
+func (genRuntime) UnknownAttributeError(dst interface{}, structType, name string, value interface{}) error {
+	return &DecodeError{
+		Path:   name,
+		Type:   reflect.TypeOf(dst).Elem(),
+		Actual: actualAttributeType(value),
+		Err:    errors.Join(ErrUnknownAttribute, fmt.Errorf("%q has no corresponding field in %s", name, structType)),
+	}
+}
+
+func (genRuntime) EncodeField(src interface{}, options string) (types.AttributeValue, error) {
+	var t structtag.Tag
+	if options != "" {
+		structtag.ParseOptions(&t, ","+options)
+	}
+	rv := reflect.ValueOf(src).Elem()
+	if state, ok := optionalStateOf(rv); ok && state == OptionalMissing {
+		return nil, nil
+	}
+	return defaultEncoder.encodeField(rv, t)
+}
+
+func (genRuntime) EncodeFieldError(err error, name, field string) error {
+	return withEncodePath(err, name, field)
+}
@@ -0,0 +1,140 @@
+// Package genruntime provides the functions called by the Scan and Value methods generated by sqldav-gen.
+//
+// They convert the values with the default sqldav.Encoder and sqldav.Decoder, like the reflective path,
+// but take the fast path without reflection for the common values.
+// They are not intended to be called directly.
+package genruntime
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/miyamo2/sqldav/internal/genhook"
+	"math"
+	"strconv"
+
+	// sqldav registers the genhook.Runtime when it is initialized.
+	_ "github.com/miyamo2/sqldav"
+)
+
+// AttributesOf returns the attributes of the map value. Returns nil if the value is NULL.
+func AttributesOf(value interface{}) (map[string]interface{}, error) {
+	return genhook.Default.AttributesOf(value)
+}
+
+// DecodeString assigns the value to the string pointed to by dst.
+func DecodeString[T ~string](dst *T, value interface{}) error {
+	if s, ok := genhook.Default.StringOf(value); ok {
+		*dst = T(s)
+		return nil
+	}
+	return DecodeField(dst, value)
+}
+
+// DecodeInt assigns the value to the integer pointed to by dst.
+func DecodeInt[T ~int | ~int8 | ~int16 | ~int32 | ~int64](dst *T, value interface{}) error {
+	var (
+		i  int64
+		ok bool
+	)
+	switch value := value.(type) {
+	case *types.AttributeValueMemberN:
+		v, err := strconv.ParseInt(value.Value, 10, 64)
+		i, ok = v, err == nil
+	case float64:
+		if value >= math.MinInt64 && value < math.MaxInt64 && value == math.Trunc(value) {
+			i, ok = int64(value), true
+		}
+	}
+	if ok && int64(T(i)) == i {
+		*dst = T(i)
+		return nil
+	}
+	return DecodeField(dst, value)
+}
+
+// DecodeUint assigns the value to the unsigned integer pointed to by dst.
+func DecodeUint[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr](dst *T, value interface{}) error {
+	var (
+		u  uint64
+		ok bool
+	)
+	switch value := value.(type) {
+	case *types.AttributeValueMemberN:
+		v, err := strconv.ParseUint(value.Value, 10, 64)
+		u, ok = v, err == nil
+	case float64:
+		if value >= 0 && value < math.MaxUint64 && value == math.Trunc(value) {
+			u, ok = uint64(value), true
+		}
+	}
+	if ok && uint64(T(u)) == u {
+		*dst = T(u)
+		return nil
+	}
+	return DecodeField(dst, value)
+}
+
+// DecodeFloat assigns the value to the float pointed to by dst.
+func DecodeFloat[T ~float32 | ~float64](dst *T, value interface{}) error {
+	var (
+		f  float64
+		ok bool
+	)
+	switch value := value.(type) {
+	case *types.AttributeValueMemberN:
+		v, err := strconv.ParseFloat(value.Value, 64)
+		f, ok = v, err == nil
+	case float64:
+		f, ok = value, true
+	}
+	if ok && !math.IsInf(float64(T(f)), 0) {
+		*dst = T(f)
+		return nil
+	}
+	return DecodeField(dst, value)
+}
+
+// DecodeBool assigns the value to the bool pointed to by dst.
+func DecodeBool[T ~bool](dst *T, value interface{}) error {
+	if b, ok := genhook.Default.BoolOf(value); ok {
+		*dst = T(b)
+		return nil
+	}
+	return DecodeField(dst, value)
+}
+
+// DecodeField assigns the attribute returned by AttributesOf to the Go value pointed to by dst with the default Decoder.
+// The attribute is not normalized by the DriverAdapter again.
+func DecodeField(dst interface{}, value interface{}) error {
+	return genhook.Default.DecodeField(dst, value)
+}
+
+// DecodeFieldError adds the attribute name and the Go struct field to the error returned by the Decode functions.
+func DecodeFieldError(err error, name, field string) error {
+	return genhook.Default.DecodeFieldError(err, name, field)
+}
+
+// MissingAttributeError returns the error for the attribute that is required by the struct field pointed to by dst.
+func MissingAttributeError(dst interface{}, structType, name, field string) error {
+	return genhook.Default.MissingAttributeError(dst, structType, name, field)
+}
+
+// DisallowsUnknownAttributes reports whether the default Decoder returns an error for unknown attributes.
+func DisallowsUnknownAttributes() bool {
+	return genhook.Default.DisallowsUnknownAttributes()
+}
+
+// UnknownAttributeError returns the error for the attribute that has no corresponding field in the struct pointed to by dst.
+func UnknownAttributeError(dst interface{}, structType, name string, value interface{}) error {
+	return genhook.Default.UnknownAttributeError(dst, structType, name, value)
+}
+
+// EncodeField converts the Go value pointed to by src to a types.AttributeValue with the default Encoder
+// and the comma-separated tag options such as "omitempty,stringset". Returns nil if the value is omitted.
+func EncodeField(src interface{}, options string) (types.AttributeValue, error) {
+	return genhook.Default.EncodeField(src, options)
+}
+
+// EncodeFieldError adds the attribute name and the Go struct field to the error returned by EncodeField.
+func EncodeFieldError(err error, name, field string) error {
+	return genhook.Default.EncodeFieldError(err, name, field)
+}
@@ -0,0 +1,77 @@
+package genruntime
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/miyamo2/sqldav"
+	"math"
+	"testing"
+)
+
+func TestDecodeInt(t *testing.T) {
+	type test struct {
+		value interface{}
+		want  int8
+		err   error
+	}
+	tests := map[string]test{
+		"happy-path/float64": {
+			value: float64(-1),
+			want:  -1,
+		},
+		"happy-path/attribute-value": {
+			value: &types.AttributeValueMemberN{Value: "127"},
+			want:  math.MaxInt8,
+		},
+		"unhappy-path/overflow": {
+			value: float64(128),
+			err:   sqldav.ErrNumberOverflow,
+		},
+		"unhappy-path/fraction": {
+			value: float64(1.5),
+			err:   sqldav.ErrNumberIsNotInteger,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got int8
+			err := DecodeInt(&got, tt.value)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("DecodeInt() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("DecodeInt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttributesOf(t *testing.T) {
+	got, err := AttributesOf(map[string]interface{}{"a": "b"})
+	if err != nil {
+		t.Fatalf("AttributesOf() error = %v", err)
+	}
+	want := map[string]interface{}{"a": "b"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("AttributesOf() mismatch (-want +got):\n%s", diff)
+	}
+	got, err = AttributesOf(nil)
+	if err != nil || got != nil {
+		t.Errorf("AttributesOf(nil) = %v, %v, want nil", got, err)
+	}
+	if _, err := AttributesOf("a"); !errors.Is(err, sqldav.ErrFailedToCast) {
+		t.Errorf("AttributesOf() error = %v, want %v", err, sqldav.ErrFailedToCast)
+	}
+}
+
+func TestEncodeField_MissingOptional(t *testing.T) {
+	missing := sqldav.Optional[string]{V: "a"}
+	got, err := EncodeField(&missing, "")
+	if err != nil {
+		t.Fatalf("EncodeField() error = %v", err)
+	}
+	if got != nil {
+		t.Errorf("EncodeField() = %v, want nil", got)
+	}
+}
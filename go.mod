@@ -6,11 +6,9 @@ require (
 	github.com/aws/aws-sdk-go-v2 v1.32.7
 	github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue v1.15.15
 	github.com/aws/aws-sdk-go-v2/service/dynamodb v1.36.5
+	github.com/aws/aws-sdk-go-v2/service/dynamodbstreams v1.24.5
 	github.com/google/go-cmp v0.6.0
 	github.com/iancoleman/strcase v0.3.0
 )
 
-require (
-	github.com/aws/aws-sdk-go-v2/service/dynamodbstreams v1.24.5 // indirect
-	github.com/aws/smithy-go v1.22.1 // indirect
-)
+require github.com/aws/smithy-go v1.22.1 // indirect
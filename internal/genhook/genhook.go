@@ -0,0 +1,35 @@
+// Package genhook connects the genruntime package to the Encoder and the Decoder of sqldav.
+//
+// sqldav registers its Runtime in Default when it is initialized, so that genruntime can use the unexported
+// parts of sqldav without exporting them from the root package.
+package genhook
+
+import "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+// Runtime converts the struct fields for the code generated by sqldav-gen with the default Encoder and Decoder.
+type Runtime interface {
+	// AttributesOf returns the attributes of the map value. Returns nil if the value is NULL.
+	AttributesOf(value interface{}) (map[string]interface{}, error)
+	// StringOf returns the string of the value, and reports whether the value is a string.
+	StringOf(value interface{}) (string, bool)
+	// BoolOf returns the bool of the value, and reports whether the value is a bool.
+	BoolOf(value interface{}) (bool, bool)
+	// DecodeField assigns the attribute returned by AttributesOf to the Go value pointed to by dst.
+	DecodeField(dst interface{}, value interface{}) error
+	// DecodeFieldError adds the attribute name and the Go struct field to the error.
+	DecodeFieldError(err error, name, field string) error
+	// MissingAttributeError returns the error for the attribute that is required by the struct field pointed to by dst.
+	MissingAttributeError(dst interface{}, structType, name, field string) error
+	// DisallowsUnknownAttributes reports whether the default Decoder returns an error for unknown attributes.
+	DisallowsUnknownAttributes() bool
+	// UnknownAttributeError returns the error for the attribute that has no corresponding field in the struct pointed to by dst.
+	UnknownAttributeError(dst interface{}, structType, name string, value interface{}) error
+	// EncodeField converts the Go value pointed to by src with the comma-separated tag options.
+	// Returns nil if the value is omitted.
+	EncodeField(src interface{}, options string) (types.AttributeValue, error)
+	// EncodeFieldError adds the attribute name and the Go struct field to the error.
+	EncodeFieldError(err error, name, field string) error
+}
+
+// Default is the Runtime registered by sqldav.
+var Default Runtime
@@ -0,0 +1,149 @@
+// Package structtag resolves the attribute name and the options of a struct field from its tags.
+//
+// It is shared by sqldav and sqldav-gen, so that the generated code uses the same attribute names as the reflective path.
+package structtag
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// reXORMColumnName matches column name from xorm tag
+var reXORMColumnName = regexp.MustCompile(`'(.*?)'`)
+
+// Tag is the attribute name and the options of the struct field.
+//
+// The options have the same meaning as the ones of the AWS SDK's attributevalue package.
+type Tag struct {
+	// Name is the attribute name.
+	Name string
+	// Tagged reports whether the name is specified by a tag rather than the naming strategy.
+	Tagged        bool
+	Ignore        bool
+	Inline        bool
+	Required      bool
+	OmitEmpty     bool
+	OmitEmptyElem bool
+	NullEmpty     bool
+	NullEmptyElem bool
+	AsStringSet   bool
+	AsNumberSet   bool
+	AsBinarySet   bool
+}
+
+// AsSet reports whether the field is converted to a set by the tag.
+func (t Tag) AsSet() bool {
+	return t.AsStringSet || t.AsNumberSet || t.AsBinarySet
+}
+
+// ElemTag returns the Tag applied to the elements of the list or the map.
+func (t Tag) ElemTag() Tag {
+	return Tag{OmitEmpty: t.OmitEmptyElem, NullEmpty: t.NullEmptyElem}
+}
+
+// HasEncodeOptions reports whether the tag has any option that changes the encoding of the value.
+func (t Tag) HasEncodeOptions() bool {
+	return t.OmitEmpty || t.OmitEmptyElem || t.NullEmpty || t.NullEmptyElem || t.AsSet()
+}
+
+// Parse returns the Tag of the struct field.
+//
+// The name is read from the first tag of the keys that specifies it, in order of precedence,
+// and otherwise from attributeName applied to the field name.
+// The options and "-" are read from the first comma-separated tag, such as dynamodbav and json, that specifies them,
+// among the tags up to the one that specifies the name and the lower ones that specify the same name.
+// So `db:"nick" dynamodbav:"nick,omitempty"` has the omitempty option, but `db:"name" json:"nick,omitempty"` does not.
+func Parse(fieldName string, tag reflect.StructTag, keys []string, attributeName func(string) string) Tag {
+	var (
+		t          Tag
+		hasOptions bool
+	)
+	for _, key := range keys {
+		value, ok := tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		name := nameFromTag(key, value)
+		sameAttribute := t.Name == "" || name == t.Name
+		if !hasOptions && sameAttribute && key != "gorm" && key != "xorm" && hasOptionsOrIgnore(value) {
+			hasOptions = true
+			if ParseOptions(&t, value) {
+				return Tag{Ignore: true}
+			}
+		}
+		if t.Name == "" {
+			t.Name = name
+		}
+	}
+	t.Tagged = t.Name != ""
+	if !t.Tagged {
+		t.Name = attributeName(fieldName)
+	}
+	return t
+}
+
+// hasOptionsOrIgnore reports whether the comma-separated tag specifies the options or "-".
+func hasOptionsOrIgnore(tag string) bool {
+	return tag == "-" || strings.Contains(tag, ",")
+}
+
+// ParseOptions parses the options of the comma-separated tag into t. It reports whether the field is ignored by "-".
+func ParseOptions(t *Tag, tag string) bool {
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		t.Ignore = true
+		return true
+	}
+	for _, opt := range strings.Split(opts, ",") {
+		switch opt {
+		case "omitempty":
+			t.OmitEmpty = true
+		case "omitemptyelem":
+			t.OmitEmptyElem = true
+		case "nullempty":
+			t.NullEmpty = true
+		case "nullemptyelem":
+			t.NullEmptyElem = true
+		case "stringset":
+			t.AsStringSet = true
+		case "numberset":
+			t.AsNumberSet = true
+		case "binaryset":
+			t.AsBinarySet = true
+		case "inline":
+			t.Inline = true
+		case "required":
+			t.Required = true
+		}
+	}
+	return false
+}
+
+// nameFromTag returns the attribute name specified by the tag.
+func nameFromTag(key, tag string) string {
+	if tag == "" {
+		return ""
+	}
+	switch key {
+	case "gorm":
+		for _, value := range strings.Split(tag, ";") {
+			k, v, ok := strings.Cut(value, ":")
+			if !ok {
+				continue
+			}
+			if k == "column" {
+				return v
+			}
+		}
+		return ""
+	case "xorm":
+		matches := reXORMColumnName.FindStringSubmatch(tag)
+		if len(matches) > 1 {
+			return matches[1]
+		}
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name
+}
@@ -0,0 +1,89 @@
+package structtag
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	type args struct {
+		fieldName string
+		tag       reflect.StructTag
+	}
+	type test struct {
+		args args
+		want Tag
+	}
+	keys := []string{"gorm", "db", "xorm", "dynamodbav", "json"}
+	tests := map[string]test{
+		"no-tag": {
+			args: args{fieldName: "UserID"},
+			want: Tag{Name: "userid"},
+		},
+		"gorm": {
+			args: args{fieldName: "Name", tag: `gorm:"type:string;column:user_name"`},
+			want: Tag{Name: "user_name", Tagged: true},
+		},
+		"xorm": {
+			args: args{fieldName: "Name", tag: `xorm:"varchar(25) notnull 'user_name'"`},
+			want: Tag{Name: "user_name", Tagged: true},
+		},
+		"gorm-without-column": {
+			args: args{fieldName: "Name", tag: `gorm:"type:string" json:"name"`},
+			want: Tag{Name: "name", Tagged: true},
+		},
+		"precedence": {
+			args: args{fieldName: "Name", tag: `json:"json_name" db:"db_name"`},
+			want: Tag{Name: "db_name", Tagged: true},
+		},
+		"options-from-first-comma-separated-tag": {
+			args: args{fieldName: "Roles", tag: `dynamodbav:",omitempty,stringset" json:"roles,nullempty"`},
+			want: Tag{Name: "roles", Tagged: true, OmitEmpty: true, AsStringSet: true},
+		},
+		"options-after-name-only-tag": {
+			args: args{fieldName: "Nickname", tag: `db:"nick" dynamodbav:"nick,omitempty"`},
+			want: Tag{Name: "nick", Tagged: true, OmitEmpty: true},
+		},
+		"set-option-after-name-only-tag": {
+			args: args{fieldName: "Tags", tag: `db:"tags" dynamodbav:"tags,stringset"`},
+			want: Tag{Name: "tags", Tagged: true, AsStringSet: true},
+		},
+		"options-after-name-only-tag-of-other-name": {
+			args: args{fieldName: "Name", tag: `db:"name" json:"nick,omitempty"`},
+			want: Tag{Name: "name", Tagged: true},
+		},
+		"options-without-name-after-name-only-tag": {
+			args: args{fieldName: "Name", tag: `db:"name" json:",omitempty"`},
+			want: Tag{Name: "name", Tagged: true},
+		},
+		"ignore-after-name-only-tag-of-other-name": {
+			args: args{fieldName: "Secret", tag: `db:"secret" json:"-"`},
+			want: Tag{Name: "secret", Tagged: true},
+		},
+		"ignore": {
+			args: args{fieldName: "Secret", tag: `dynamodbav:"-" json:"secret"`},
+			want: Tag{Ignore: true},
+		},
+		"all-options": {
+			args: args{
+				fieldName: "Tags",
+				tag:       `dynamodbav:"tags,omitempty,omitemptyelem,nullempty,nullemptyelem,numberset,binaryset,inline,required"`,
+			},
+			want: Tag{
+				Name: "tags", Tagged: true, Inline: true, Required: true,
+				OmitEmpty: true, OmitEmptyElem: true, NullEmpty: true, NullEmptyElem: true,
+				AsNumberSet: true, AsBinarySet: true,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := Parse(tt.args.fieldName, tt.args.tag, keys, strings.ToLower)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -163,3 +163,36 @@ func TestList_Value(t *testing.T) {
 		})
 	}
 }
+
+func TestList_Scan_AttributeValue(t *testing.T) {
+	var got List
+	err := got.Scan(&types.AttributeValueMemberL{Value: []types.AttributeValue{
+		&types.AttributeValueMemberS{Value: "a"},
+		&types.AttributeValueMemberN{Value: "1"},
+		&types.AttributeValueMemberBOOL{Value: true},
+		&types.AttributeValueMemberNULL{Value: true},
+		&types.AttributeValueMemberSS{Value: []string{"b"}},
+		&types.AttributeValueMemberNS{Value: []string{"2"}},
+		&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"l": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberB{Value: []byte("c")}}},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	want := List{"a", float64(1), true, nil, Set[string]{"b"}, Set[int]{2}, Map{"l": List{[]byte("c")}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+	}
+
+	// the elements of the driver value may be types.AttributeValue too.
+	got = nil
+	if err := got.Scan([]interface{}{&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"s": &types.AttributeValueMemberS{Value: "a"},
+	}}}); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if diff := cmp.Diff(List{Map{"s": "a"}}, got); diff != "" {
+		t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -154,3 +154,32 @@ func TestMap_Value(t *testing.T) {
 		})
 	}
 }
+
+func TestMap_Scan_AttributeValue(t *testing.T) {
+	var got Map
+	err := got.Scan(&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"s":  &types.AttributeValueMemberS{Value: "a"},
+		"n":  &types.AttributeValueMemberN{Value: "1.5"},
+		"bs": &types.AttributeValueMemberBS{Value: [][]byte{[]byte("b")}},
+		"l":  &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberSS{Value: []string{"c"}}}},
+		"m":  &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"null": &types.AttributeValueMemberNULL{Value: true}}},
+	}})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	want := Map{
+		"s":  "a",
+		"n":  1.5,
+		"bs": Set[[]byte]{[]byte("b")},
+		"l":  List{Set[string]{"c"}},
+		"m":  Map{"null": nil},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+	}
+
+	got = nil
+	if err := got.Scan(&types.AttributeValueMemberS{Value: "a"}); !errors.Is(err, ErrFailedToCast) {
+		t.Errorf("Scan() error = %v, want %v", err, ErrFailedToCast)
+	}
+}
@@ -0,0 +1,43 @@
+package sqldav
+
+import (
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"reflect"
+)
+
+// AttributeValueMarshaler is implemented by the types that convert themselves to a types.AttributeValue
+// when they are nested in documents such as Map, TypedList and structs.
+//
+// It takes precedence over driver.Valuer, so that a type can be represented differently as a column and in a document.
+// The AWS SDK's attributevalue.Marshaler is also honored.
+type AttributeValueMarshaler interface {
+	MarshalAttributeValue() (types.AttributeValue, error)
+}
+
+// AttributeValueUnmarshaler is implemented by the types that assign a types.AttributeValue to themselves
+// when they are nested in documents such as Map, TypedList and structs.
+//
+// It takes precedence over sql.Scanner. The values from the database/sql driver, such as the elements of []interface{},
+// are converted to types.AttributeValue before UnmarshalAttributeValue is called, and NULL is passed as well.
+// The AWS SDK's attributevalue.Unmarshaler is also honored.
+type AttributeValueUnmarshaler interface {
+	UnmarshalAttributeValue(av types.AttributeValue) error
+}
+
+var (
+	attributeValueMarshalerType      = reflect.TypeOf((*AttributeValueMarshaler)(nil)).Elem()
+	attributeValueUnmarshalerType    = reflect.TypeOf((*AttributeValueUnmarshaler)(nil)).Elem()
+	sdkAttributeValueMarshalerType   = reflect.TypeOf((*attributevalue.Marshaler)(nil)).Elem()
+	sdkAttributeValueUnmarshalerType = reflect.TypeOf((*attributevalue.Unmarshaler)(nil)).Elem()
+)
+
+// implementsMarshaler reports whether the type implements AttributeValueMarshaler or attributevalue.Marshaler.
+func implementsMarshaler(rt reflect.Type) bool {
+	return rt.Implements(attributeValueMarshalerType) || rt.Implements(sdkAttributeValueMarshalerType)
+}
+
+// implementsUnmarshaler reports whether the type implements AttributeValueUnmarshaler or attributevalue.Unmarshaler.
+func implementsUnmarshaler(rt reflect.Type) bool {
+	return rt.Implements(attributeValueUnmarshalerType) || rt.Implements(sdkAttributeValueUnmarshalerType)
+}
@@ -0,0 +1,280 @@
+package sqldav
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// celsius implements AttributeValueMarshaler and AttributeValueUnmarshaler.
+type celsius float64
+
+func (c celsius) MarshalAttributeValue() (types.AttributeValue, error) {
+	return &types.AttributeValueMemberS{Value: strconv.FormatFloat(float64(c), 'f', -1, 64) + "C"}, nil
+}
+
+func (c *celsius) UnmarshalAttributeValue(av types.AttributeValue) error {
+	s, ok := av.(*types.AttributeValueMemberS)
+	if !ok || !strings.HasSuffix(s.Value, "C") {
+		return fmt.Errorf("incompatible celsius and %T", av)
+	}
+	f, err := strconv.ParseFloat(strings.TrimSuffix(s.Value, "C"), 64)
+	*c = celsius(f)
+	return err
+}
+
+// money implements the AWS SDK's attributevalue.Marshaler and attributevalue.Unmarshaler with pointer receivers.
+type money struct {
+	Currency string
+	Units    int64
+}
+
+func (m *money) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return &types.AttributeValueMemberS{Value: fmt.Sprintf("%s %d", m.Currency, m.Units)}, nil
+}
+
+func (m *money) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	s, ok := av.(*types.AttributeValueMemberS)
+	if !ok {
+		return fmt.Errorf("incompatible money and %T", av)
+	}
+	_, err := fmt.Sscanf(s.Value, "%s %d", &m.Currency, &m.Units)
+	return err
+}
+
+// column implements both driver.Valuer and AttributeValueMarshaler.
+type column string
+
+func (c column) Value() (interface{}, error) {
+	return "valuer", nil
+}
+
+func (c column) MarshalAttributeValue() (types.AttributeValue, error) {
+	return &types.AttributeValueMemberS{Value: "marshaler"}, nil
+}
+
+type reading struct {
+	Temperature celsius
+	Price       money
+	Column      column
+}
+
+func TestAttributeValueMarshaler_Marshal(t *testing.T) {
+	price := &types.AttributeValueMemberS{Value: "JPY 100"}
+	temperature := &types.AttributeValueMemberS{Value: "12.5C"}
+	type test struct {
+		args interface{}
+		want types.AttributeValue
+	}
+	tests := map[string]test{
+		"struct": {
+			args: reading{Temperature: 12.5, Price: money{Currency: "JPY", Units: 100}, Column: "x"},
+			want: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"temperature": temperature,
+				"price":       price,
+				"column":      &types.AttributeValueMemberS{Value: "marshaler"},
+			}},
+		},
+		"typed-list": {
+			args: TypedList[celsius]{12.5},
+			want: &types.AttributeValueMemberL{Value: []types.AttributeValue{temperature}},
+		},
+		"list": {
+			args: List{celsius(12.5), &money{Currency: "JPY", Units: 100}},
+			want: &types.AttributeValueMemberL{Value: []types.AttributeValue{temperature, price}},
+		},
+		"map": {
+			args: Map{"temperature": celsius(12.5)},
+			want: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"temperature": temperature}},
+		},
+		"typed-map": {
+			args: TypedMap[*money]{"price": {Currency: "JPY", Units: 100}, "none": nil},
+			want: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"price": price,
+				"none":  &types.AttributeValueMemberNULL{Value: true},
+			}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Marshal(tt.args)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, attributeValueCmpOptions()...); diff != "" {
+				t.Errorf("Marshal() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAttributeValueUnmarshaler_Unmarshal(t *testing.T) {
+	av := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"temperature": &types.AttributeValueMemberS{Value: "12.5C"},
+		"price":       &types.AttributeValueMemberS{Value: "JPY 100"},
+	}}
+	var got reading
+	if err := Unmarshal(av, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := reading{Temperature: 12.5, Price: money{Currency: "JPY", Units: 100}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAttributeValueUnmarshaler_Scan(t *testing.T) {
+	var got TypedList[reading]
+	err := got.Scan([]interface{}{
+		map[string]interface{}{"temperature": "12.5C", "price": "JPY 100"},
+	})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	want := TypedList[reading]{{Temperature: 12.5, Price: money{Currency: "JPY", Units: 100}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAttributeValueUnmarshaler_Error(t *testing.T) {
+	var got TypedMap[celsius]
+	err := got.Scan(map[string]interface{}{"temperature": float64(12.5)})
+	var de *DecodeError
+	if !errors.As(err, &de) || de.Path != "temperature" {
+		t.Errorf("Scan() error = %v, want the path temperature", err)
+	}
+}
+
+// legacyColumn implements both sql.Scanner and driver.Valuer, and the AWS SDK's attributevalue.Marshaler and attributevalue.Unmarshaler.
+type legacyColumn string
+
+func (c *legacyColumn) Scan(value interface{}) error {
+	*c = legacyColumn(fmt.Sprintf("scanner %v", value))
+	return nil
+}
+
+func (c legacyColumn) Value() (driver.Value, error) {
+	return "valuer", nil
+}
+
+func (c *legacyColumn) UnmarshalDynamoDBAttributeValue(types.AttributeValue) error {
+	*c = "sdk"
+	return nil
+}
+
+func (c legacyColumn) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return &types.AttributeValueMemberS{Value: "sdk"}, nil
+}
+
+func TestAttributeValueMarshaler_ScannerPrecedence(t *testing.T) {
+	var got TypedMap[legacyColumn]
+	if err := got.Scan(map[string]interface{}{"c": "x"}); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if diff := cmp.Diff(TypedMap[legacyColumn]{"c": "scanner x"}, got); diff != "" {
+		t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+	}
+	av, err := Marshal(got)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"c": &types.AttributeValueMemberS{Value: "valuer"}}}
+	if diff := cmp.Diff(want, av, attributeValueCmpOptions()...); diff != "" {
+		t.Errorf("Marshal() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type sdkItem struct {
+	Name string
+	Qty  int
+}
+
+// sdkRecord is marshaled by the AWS SDK's attributevalue package.
+type sdkRecord struct {
+	Tags   Set[string]        `dynamodbav:"tags"`
+	Scores Set[int]           `dynamodbav:"scores"`
+	Blobs  Set[[]byte]        `dynamodbav:"blobs"`
+	Empty  Set[string]        `dynamodbav:"empty"`
+	Attrs  List               `dynamodbav:"attrs"`
+	Meta   Map                `dynamodbav:"meta"`
+	Items  TypedList[sdkItem] `dynamodbav:"items"`
+	Labels TypedMap[string]   `dynamodbav:"labels"`
+	Doc    Document[*sdkItem] `dynamodbav:"doc"`
+}
+
+func TestMarshalDynamoDBAttributeValue(t *testing.T) {
+	v := sdkRecord{
+		Tags:   Set[string]{"b", "a"},
+		Scores: Set[int]{2, 1},
+		Blobs:  Set[[]byte]{[]byte("x")},
+		Attrs:  List{"a", float64(1)},
+		Meta:   Map{"k": "v"},
+		Items:  TypedList[sdkItem]{{Name: "apple", Qty: 3}},
+		Labels: TypedMap[string]{"env": "prod"},
+		Doc:    Document[*sdkItem]{Data: &sdkItem{Name: "pear", Qty: 1}},
+	}
+	item := func(name, qty string) types.AttributeValue {
+		return &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"name": &types.AttributeValueMemberS{Value: name},
+			"qty":  &types.AttributeValueMemberN{Value: qty},
+		}}
+	}
+	want := map[string]types.AttributeValue{
+		"tags":   &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+		"scores": &types.AttributeValueMemberNS{Value: []string{"1", "2"}},
+		"blobs":  &types.AttributeValueMemberBS{Value: [][]byte{[]byte("x")}},
+		"empty":  &types.AttributeValueMemberNULL{Value: true},
+		"attrs": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberS{Value: "a"},
+			&types.AttributeValueMemberN{Value: "1"},
+		}},
+		"meta":   &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"k": &types.AttributeValueMemberS{Value: "v"}}},
+		"items":  &types.AttributeValueMemberL{Value: []types.AttributeValue{item("apple", "3")}},
+		"labels": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"env": &types.AttributeValueMemberS{Value: "prod"}}},
+		"doc":    item("pear", "1"),
+	}
+
+	got, err := attributevalue.MarshalMap(v)
+	if err != nil {
+		t.Fatalf("attributevalue.MarshalMap() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got, attributeValueCmpOptions()...); diff != "" {
+		t.Errorf("attributevalue.MarshalMap() mismatch (-want +got):\n%s", diff)
+	}
+
+	var decoded sdkRecord
+	if err := attributevalue.UnmarshalMap(got, &decoded); err != nil {
+		t.Fatalf("attributevalue.UnmarshalMap() error = %v", err)
+	}
+	v.Tags = Set[string]{"a", "b"}
+	v.Scores = Set[int]{1, 2}
+	if diff := cmp.Diff(v, decoded); diff != "" {
+		t.Errorf("attributevalue.UnmarshalMap() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshalDynamoDBAttributeValue_Replace(t *testing.T) {
+	l := TypedList[int]{1}
+	if err := l.UnmarshalDynamoDBAttributeValue(&types.AttributeValueMemberL{Value: []types.AttributeValue{
+		&types.AttributeValueMemberN{Value: "2"},
+	}}); err != nil {
+		t.Fatalf("UnmarshalDynamoDBAttributeValue() error = %v", err)
+	}
+	if diff := cmp.Diff(TypedList[int]{2}, l); diff != "" {
+		t.Errorf("UnmarshalDynamoDBAttributeValue() mismatch (-want +got):\n%s", diff)
+	}
+	m := Map{"k": "v"}
+	if err := m.UnmarshalDynamoDBAttributeValue(&types.AttributeValueMemberNULL{Value: true}); err != nil {
+		t.Fatalf("UnmarshalDynamoDBAttributeValue() error = %v", err)
+	}
+	if m != nil {
+		t.Errorf("UnmarshalDynamoDBAttributeValue() = %v, want nil", m)
+	}
+}
@@ -0,0 +1,42 @@
+package sqldav
+
+import (
+	"github.com/iancoleman/strcase"
+)
+
+// NamingStrategy resolves the attribute name from the name of the struct field that has no tag for the attribute name.
+type NamingStrategy interface {
+	// AttributeName returns the attribute name of the struct field.
+	AttributeName(fieldName string) string
+}
+
+// NamingStrategyFunc is an adapter to allow the use of ordinary functions as NamingStrategy.
+type NamingStrategyFunc func(fieldName string) string
+
+// AttributeName implements NamingStrategy.
+func (f NamingStrategyFunc) AttributeName(fieldName string) string {
+	return f(fieldName)
+}
+
+// SnakeCase converts the field name to snake_case. e.g. `UserID` to `user_id`.
+//
+// This is the default NamingStrategy.
+var SnakeCase NamingStrategy = NamingStrategyFunc(strcase.ToSnake)
+
+// CamelCase converts the field name to camelCase. e.g. `UserID` to `userId`.
+var CamelCase NamingStrategy = NamingStrategyFunc(func(fieldName string) string {
+	return strcase.ToLowerCamel(strcase.ToSnake(fieldName))
+})
+
+// PascalCase converts the field name to PascalCase. e.g. `HTTPServer` to `HttpServer`.
+var PascalCase NamingStrategy = NamingStrategyFunc(func(fieldName string) string {
+	return strcase.ToCamel(strcase.ToSnake(fieldName))
+})
+
+// KebabCase converts the field name to kebab-case. e.g. `UserID` to `user-id`.
+var KebabCase NamingStrategy = NamingStrategyFunc(strcase.ToKebab)
+
+// Identity uses the field name as it is. e.g. `UserID` to `UserID`.
+var Identity NamingStrategy = NamingStrategyFunc(func(fieldName string) string {
+	return fieldName
+})
@@ -0,0 +1,91 @@
+package sqldav
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestNamingStrategy_AttributeName(t *testing.T) {
+	type test struct {
+		sut  NamingStrategy
+		args string
+		want string
+	}
+	tests := map[string]test{
+		"snake-case":               {sut: SnakeCase, args: "UserID", want: "user_id"},
+		"camel-case":               {sut: CamelCase, args: "UserID", want: "userId"},
+		"camel-case/acronym":       {sut: CamelCase, args: "HTTPServer", want: "httpServer"},
+		"pascal-case":              {sut: PascalCase, args: "userName", want: "UserName"},
+		"pascal-case/acronym":      {sut: PascalCase, args: "HTTPServer", want: "HttpServer"},
+		"kebab-case":               {sut: KebabCase, args: "UserID", want: "user-id"},
+		"identity":                 {sut: Identity, args: "UserID", want: "UserID"},
+		"naming-strategy-function": {sut: NamingStrategyFunc(func(string) string { return "x" }), args: "UserID", want: "x"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.sut.AttributeName(tt.args); got != tt.want {
+				t.Errorf("AttributeName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type namingTarget struct {
+	UserID    string
+	FirstName string `dynamodbav:"given_name,omitempty" json:"firstName"`
+	LastName  string `json:"lastName" gorm:"column:family_name"`
+}
+
+func TestSetDefaultNamingStrategy(t *testing.T) {
+	SetDefaultNamingStrategy(CamelCase)
+	SetDefaultTagKeys("dynamodbav", "json")
+	t.Cleanup(func() {
+		SetDefaultNamingStrategy(SnakeCase)
+		SetDefaultTagKeys(defaultTagKeys...)
+	})
+	opts := []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberM{}),
+	}
+	v := namingTarget{UserID: "u1", FirstName: "Taro", LastName: "Yamada"}
+
+	got, err := Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"userId":     &types.AttributeValueMemberS{Value: "u1"},
+		"given_name": &types.AttributeValueMemberS{Value: "Taro"},
+		"lastName":   &types.AttributeValueMemberS{Value: "Yamada"},
+	}}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Errorf("Marshal() mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err = NewEncoder(WithNamingStrategy(PascalCase), WithTagKeys("gorm", "json")).Encode(v)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want = &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"UserId":      &types.AttributeValueMemberS{Value: "u1"},
+		"firstName":   &types.AttributeValueMemberS{Value: "Taro"},
+		"family_name": &types.AttributeValueMemberS{Value: "Yamada"},
+	}}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Errorf("Encode() mismatch (-want +got):\n%s", diff)
+	}
+
+	var decoded namingTarget
+	err = Unmarshal(&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"userId":     &types.AttributeValueMemberS{Value: "u1"},
+		"given_name": &types.AttributeValueMemberS{Value: "Taro"},
+		"lastName":   &types.AttributeValueMemberS{Value: "Yamada"},
+	}}, &decoded)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if diff := cmp.Diff(v, decoded); diff != "" {
+		t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+	}
+}
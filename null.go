@@ -0,0 +1,279 @@
+package sqldav
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"reflect"
+)
+
+// compatibility check
+var (
+	_ driver.Valuer              = (*Null[List])(nil)
+	_ sql.Scanner                = (*Null[List])(nil)
+	_ attributevalue.Marshaler   = (*Null[List])(nil)
+	_ attributevalue.Unmarshaler = (*Null[List])(nil)
+)
+
+// Null represents a value of T that may be NULL, like [sql.Null].
+//
+// T is any type that the Encoder and the Decoder support, such as the sqldav types, structs and string.
+// Valid is false if the value is NULL.
+//
+// [sql.Null]: https://pkg.go.dev/database/sql#Null
+type Null[T any] struct {
+	V     T
+	Valid bool
+}
+
+// Scan implements the [sql.Scanner#Scan]
+//
+// NULL sets Valid to false. Otherwise, the value is assigned to V and Valid is set to true.
+//
+// [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
+func (n *Null[T]) Scan(value interface{}) error {
+	return scanWithDefaultDecoder(n, value)
+}
+
+// decodeWith assigns the value to V with the Decoder, unless the value is NULL.
+func (n *Null[T]) decodeWith(d *Decoder, value interface{}) error {
+	var v T
+	if isNull(value) {
+		n.V, n.Valid = v, false
+		return nil
+	}
+	rv := reflect.ValueOf(&v).Elem()
+	if err := d.decode(rv.Type(), rv, value); err != nil {
+		return err
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// Value implements the [driver.Valuer] interface.
+//
+// Returns *types.AttributeValueMemberNULL if Valid is false.
+//
+// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
+func (n Null[T]) Value() (driver.Value, error) {
+	return n.encodeWith(defaultEncoder)
+}
+
+// encodeWith converts V with the Encoder, or to NULL if Valid is false.
+func (n Null[T]) encodeWith(e *Encoder) (types.AttributeValue, error) {
+	if !n.Valid {
+		return newNullAttributeValue(), nil
+	}
+	return e.encode(n.V)
+}
+
+// MarshalDynamoDBAttributeValue implements the [attributevalue.Marshaler] interface.
+//
+// [attributevalue.Marshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Marshaler
+func (n Null[T]) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return n.encodeWith(defaultEncoder)
+}
+
+// UnmarshalDynamoDBAttributeValue implements the [attributevalue.Unmarshaler] interface.
+//
+// [attributevalue.Unmarshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Unmarshaler
+func (n *Null[T]) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return n.decodeWith(defaultDecoder.Load(), av)
+}
+
+// GormDataType returns the data type of T for Gorm.
+func (n *Null[T]) GormDataType() string {
+	if g, ok := interface{}(&n.V).(interface{ GormDataType() string }); ok {
+		return g.GormDataType()
+	}
+	return ""
+}
+
+// compatibility check
+var (
+	_ driver.Valuer              = (*NullSet[string])(nil)
+	_ sql.Scanner                = (*NullSet[string])(nil)
+	_ attributevalue.Marshaler   = (*NullSet[string])(nil)
+	_ attributevalue.Unmarshaler = (*NullSet[string])(nil)
+)
+
+// NullSet represents a Set that may be NULL. Valid is false if the set is NULL.
+//
+// Unlike Set, NULL is distinguished from the empty set. The empty set is still invalid in DynamoDB if Valid is true.
+type NullSet[T SetSupportable] struct {
+	Set   Set[T]
+	Valid bool
+}
+
+// Scan implements the [sql.Scanner#Scan]
+//
+// [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
+func (n *NullSet[T]) Scan(value interface{}) error {
+	return scanWithDefaultDecoder(n, value)
+}
+
+// decodeWith assigns the value to the set with the Decoder, unless the value is NULL.
+func (n *NullSet[T]) decodeWith(d *Decoder, value interface{}) error {
+	var v Null[Set[T]]
+	err := v.decodeWith(d, value)
+	n.Set, n.Valid = v.V, v.Valid
+	return err
+}
+
+// Value implements the [driver.Valuer] interface.
+//
+// Returns *types.AttributeValueMemberNULL if Valid is false.
+//
+// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
+func (n NullSet[T]) Value() (driver.Value, error) {
+	return n.encodeWith(defaultEncoder)
+}
+
+// encodeWith converts the set with the Encoder, or to NULL if Valid is false.
+func (n NullSet[T]) encodeWith(e *Encoder) (types.AttributeValue, error) {
+	return Null[Set[T]]{V: n.Set, Valid: n.Valid}.encodeWith(e)
+}
+
+// MarshalDynamoDBAttributeValue implements the [attributevalue.Marshaler] interface.
+//
+// [attributevalue.Marshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Marshaler
+func (n NullSet[T]) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return n.encodeWith(defaultEncoder)
+}
+
+// UnmarshalDynamoDBAttributeValue implements the [attributevalue.Unmarshaler] interface.
+//
+// [attributevalue.Unmarshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Unmarshaler
+func (n *NullSet[T]) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return n.decodeWith(defaultDecoder.Load(), av)
+}
+
+// GormDataType returns the data type for Gorm.
+func (n *NullSet[T]) GormDataType() string {
+	return n.Set.GormDataType()
+}
+
+// compatibility check
+var (
+	_ driver.Valuer              = (*NullList)(nil)
+	_ sql.Scanner                = (*NullList)(nil)
+	_ attributevalue.Marshaler   = (*NullList)(nil)
+	_ attributevalue.Unmarshaler = (*NullList)(nil)
+)
+
+// NullList represents a List that may be NULL. Valid is false if the list is NULL.
+type NullList struct {
+	List  List
+	Valid bool
+}
+
+// Scan implements the [sql.Scanner#Scan]
+//
+// [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
+func (n *NullList) Scan(value interface{}) error {
+	return scanWithDefaultDecoder(n, value)
+}
+
+// decodeWith assigns the value to the list with the Decoder, unless the value is NULL.
+func (n *NullList) decodeWith(d *Decoder, value interface{}) error {
+	var v Null[List]
+	err := v.decodeWith(d, value)
+	n.List, n.Valid = v.V, v.Valid
+	return err
+}
+
+// Value implements the [driver.Valuer] interface.
+//
+// Returns *types.AttributeValueMemberNULL if Valid is false.
+//
+// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
+func (n NullList) Value() (driver.Value, error) {
+	return n.encodeWith(defaultEncoder)
+}
+
+// encodeWith converts the list with the Encoder, or to NULL if Valid is false.
+func (n NullList) encodeWith(e *Encoder) (types.AttributeValue, error) {
+	return Null[List]{V: n.List, Valid: n.Valid}.encodeWith(e)
+}
+
+// MarshalDynamoDBAttributeValue implements the [attributevalue.Marshaler] interface.
+//
+// [attributevalue.Marshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Marshaler
+func (n NullList) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return n.encodeWith(defaultEncoder)
+}
+
+// UnmarshalDynamoDBAttributeValue implements the [attributevalue.Unmarshaler] interface.
+//
+// [attributevalue.Unmarshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Unmarshaler
+func (n *NullList) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return n.decodeWith(defaultDecoder.Load(), av)
+}
+
+// GormDataType returns the data type for Gorm.
+func (n *NullList) GormDataType() string {
+	return "L"
+}
+
+// compatibility check
+var (
+	_ driver.Valuer              = (*NullMap)(nil)
+	_ sql.Scanner                = (*NullMap)(nil)
+	_ attributevalue.Marshaler   = (*NullMap)(nil)
+	_ attributevalue.Unmarshaler = (*NullMap)(nil)
+)
+
+// NullMap represents a Map that may be NULL. Valid is false if the map is NULL.
+type NullMap struct {
+	Map   Map
+	Valid bool
+}
+
+// Scan implements the [sql.Scanner#Scan]
+//
+// [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
+func (n *NullMap) Scan(value interface{}) error {
+	return scanWithDefaultDecoder(n, value)
+}
+
+// decodeWith assigns the value to the map with the Decoder, unless the value is NULL.
+func (n *NullMap) decodeWith(d *Decoder, value interface{}) error {
+	var v Null[Map]
+	err := v.decodeWith(d, value)
+	n.Map, n.Valid = v.V, v.Valid
+	return err
+}
+
+// Value implements the [driver.Valuer] interface.
+//
+// Returns *types.AttributeValueMemberNULL if Valid is false.
+//
+// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
+func (n NullMap) Value() (driver.Value, error) {
+	return n.encodeWith(defaultEncoder)
+}
+
+// encodeWith converts the map with the Encoder, or to NULL if Valid is false.
+func (n NullMap) encodeWith(e *Encoder) (types.AttributeValue, error) {
+	return Null[Map]{V: n.Map, Valid: n.Valid}.encodeWith(e)
+}
+
+// MarshalDynamoDBAttributeValue implements the [attributevalue.Marshaler] interface.
+//
+// [attributevalue.Marshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Marshaler
+func (n NullMap) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return n.encodeWith(defaultEncoder)
+}
+
+// UnmarshalDynamoDBAttributeValue implements the [attributevalue.Unmarshaler] interface.
+//
+// [attributevalue.Unmarshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Unmarshaler
+func (n *NullMap) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return n.decodeWith(defaultDecoder.Load(), av)
+}
+
+// GormDataType returns the data type for Gorm.
+func (n *NullMap) GormDataType() string {
+	return "M"
+}
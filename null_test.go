@@ -0,0 +1,201 @@
+package sqldav
+
+import (
+	"database/sql/driver"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestNull_Scan(t *testing.T) {
+	type test struct {
+		sut           interface{ Scan(interface{}) error }
+		args          interface{}
+		expectedState interface{}
+		want          error
+	}
+	tests := map[string]test{
+		"happy-path/null-set-nil": {
+			sut:           &NullSet[string]{Set: Set[string]{"a"}, Valid: true},
+			args:          nil,
+			expectedState: &NullSet[string]{},
+		},
+		"happy-path/null-set-value": {
+			sut:           &NullSet[int]{},
+			args:          []float64{1, 2},
+			expectedState: &NullSet[int]{Set: Set[int]{1, 2}, Valid: true},
+		},
+		"happy-path/null-list-nil": {
+			sut:           &NullList{},
+			args:          nil,
+			expectedState: &NullList{},
+		},
+		"happy-path/null-list-null-attribute-value": {
+			sut:           &NullList{List: List{"a"}, Valid: true},
+			args:          &types.AttributeValueMemberNULL{Value: true},
+			expectedState: &NullList{},
+		},
+		"happy-path/null-list-empty": {
+			sut:           &NullList{},
+			args:          []interface{}{},
+			expectedState: &NullList{List: List{}, Valid: true},
+		},
+		"happy-path/null-map-nil": {
+			sut:           &NullMap{},
+			args:          nil,
+			expectedState: &NullMap{},
+		},
+		"happy-path/null-map-value": {
+			sut:           &NullMap{},
+			args:          map[string]interface{}{"a": []interface{}{"b"}},
+			expectedState: &NullMap{Map: Map{"a": List{"b"}}, Valid: true},
+		},
+		"happy-path/null-typed-list": {
+			sut:           &Null[TypedList[int]]{},
+			args:          []interface{}{float64(1)},
+			expectedState: &Null[TypedList[int]]{V: TypedList[int]{1}, Valid: true},
+		},
+		"happy-path/null-string": {
+			sut:           &Null[string]{},
+			args:          "a",
+			expectedState: &Null[string]{V: "a", Valid: true},
+		},
+		"happy-path/null-number-nil": {
+			sut:           &Null[Number]{V: "1", Valid: true},
+			args:          nil,
+			expectedState: &Null[Number]{},
+		},
+		"unhappy-path/null-map-incompatible": {
+			sut:           &NullMap{},
+			args:          "a",
+			expectedState: &NullMap{},
+			want:          ErrFailedToCast,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.sut.Scan(tt.args)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Scan() error = %v, want %v", err, tt.want)
+				return
+			}
+			if diff := cmp.Diff(tt.expectedState, tt.sut); diff != "" {
+				t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNull_Value(t *testing.T) {
+	null := &types.AttributeValueMemberNULL{Value: true}
+	type test struct {
+		sut  driver.Valuer
+		want interface{}
+		err  error
+	}
+	tests := map[string]test{
+		"invalid-set": {
+			sut:  NullSet[string]{Set: Set[string]{"a"}},
+			want: null,
+		},
+		"valid-set": {
+			sut:  NullSet[string]{Set: Set[string]{"a"}, Valid: true},
+			want: &types.AttributeValueMemberSS{Value: []string{"a"}},
+		},
+		"valid-empty-set": {
+			sut: NullSet[string]{Valid: true},
+			err: ErrSetIsEmpty,
+		},
+		"invalid-list": {
+			sut:  NullList{},
+			want: null,
+		},
+		"valid-empty-list": {
+			sut:  NullList{Valid: true},
+			want: &types.AttributeValueMemberL{Value: []types.AttributeValue{}},
+		},
+		"invalid-map": {
+			sut:  NullMap{Map: Map{"a": "b"}},
+			want: null,
+		},
+		"valid-map": {
+			sut:  NullMap{Map: Map{"a": "b"}, Valid: true},
+			want: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"a": &types.AttributeValueMemberS{Value: "b"}}},
+		},
+		"invalid-typed-map": {
+			sut:  Null[TypedMap[int]]{},
+			want: null,
+		},
+		"valid-string": {
+			sut:  Null[string]{V: "a", Valid: true},
+			want: &types.AttributeValueMemberS{Value: "a"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.sut.Value()
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Value() error = %v, want %v", err, tt.err)
+			}
+			if diff := cmp.Diff(tt.want, got, attributeValueCmpOptions()...); diff != "" {
+				t.Errorf("Value() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type nullable struct {
+	Tags  NullSet[string] `dynamodbav:"tags"`
+	Attrs NullMap         `dynamodbav:"attrs"`
+	Name  Null[string]    `dynamodbav:"name"`
+}
+
+func TestNull_Struct(t *testing.T) {
+	av := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"tags":  &types.AttributeValueMemberNULL{Value: true},
+		"attrs": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{}},
+	}}
+	var got nullable
+	if err := Unmarshal(av, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := nullable{Attrs: NullMap{Map: Map{}, Valid: true}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+	}
+
+	encoded, err := Marshal(got)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	wantAV := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"tags":  &types.AttributeValueMemberNULL{Value: true},
+		"attrs": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{}},
+		"name":  &types.AttributeValueMemberNULL{Value: true},
+	}}
+	if diff := cmp.Diff(wantAV, encoded, attributeValueCmpOptions()...); diff != "" {
+		t.Errorf("Marshal() mismatch (-want +got):\n%s", diff)
+	}
+
+	sdk, err := attributevalue.Marshal(got)
+	if err != nil {
+		t.Fatalf("attributevalue.Marshal() error = %v", err)
+	}
+	if diff := cmp.Diff(wantAV, sdk, attributeValueCmpOptions()...); diff != "" {
+		t.Errorf("attributevalue.Marshal() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNull_GormDataType(t *testing.T) {
+	if got := (&NullSet[int]{}).GormDataType(); got != "NS" {
+		t.Errorf("NullSet.GormDataType() = %v, want NS", got)
+	}
+	if got := (&Null[TypedMap[int]]{}).GormDataType(); got != "M" {
+		t.Errorf("Null.GormDataType() = %v, want M", got)
+	}
+	if got := (&Null[string]{}).GormDataType(); got != "" {
+		t.Errorf("Null.GormDataType() = %v, want empty", got)
+	}
+}
@@ -0,0 +1,290 @@
+package sqldav
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+)
+
+var (
+	ErrInvalidNumber                    = errors.New("invalid number")
+	ErrNumberOverflow                   = errors.New("number overflows")
+	ErrNumberIsNotInteger               = errors.New("number is not integer")
+	ErrValueIsIncompatibleOfNumber      = errors.New("value is incompatible of number")
+	ErrValueIsIncompatibleOfNumberSlice = errors.New("value is incompatible of number slice")
+)
+
+// compatibility check
+var (
+	_ driver.Valuer = (*Number)(nil)
+	_ sql.Scanner   = (*Number)(nil)
+)
+
+// Number is a DynamoDB number type.
+//
+// Number holds the decimal string as is, so it can represent any number DynamoDB supports without loss of precision.
+//
+// See: https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/HowItWorks.NamingRulesDataTypes.html
+type Number string
+
+// reNumber matches the decimal string that DynamoDB accepts as a number.
+var reNumber = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// String returns the decimal string of the number.
+func (n Number) String() string {
+	return string(n)
+}
+
+// IsValid reports whether the number is a valid decimal string.
+func (n Number) IsValid() bool {
+	return reNumber.MatchString(string(n))
+}
+
+// Int64 returns the number as int64.
+//
+// Returns ErrNumberIsNotInteger if the number has a fractional part, and ErrNumberOverflow if it overflows int64.
+func (n Number) Int64() (int64, error) {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return i, nil
+	}
+	bi, err := n.BigInt()
+	if err != nil {
+		return 0, err
+	}
+	if !bi.IsInt64() {
+		return 0, errors.Join(ErrNumberOverflow, fmt.Errorf("%s overflows int64", n))
+	}
+	return bi.Int64(), nil
+}
+
+// Uint64 returns the number as uint64.
+//
+// Returns ErrNumberIsNotInteger if the number has a fractional part, and ErrNumberOverflow if it overflows uint64.
+func (n Number) Uint64() (uint64, error) {
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return u, nil
+	}
+	bi, err := n.BigInt()
+	if err != nil {
+		return 0, err
+	}
+	if !bi.IsUint64() {
+		return 0, errors.Join(ErrNumberOverflow, fmt.Errorf("%s overflows uint64", n))
+	}
+	return bi.Uint64(), nil
+}
+
+// Float64 returns the number as float64.
+//
+// The result is the nearest float64 value.
+// Returns ErrNumberOverflow if the number overflows float64.
+func (n Number) Float64() (float64, error) {
+	if !n.IsValid() {
+		return 0, errors.Join(ErrInvalidNumber, fmt.Errorf("%q is not a number", n))
+	}
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return 0, errors.Join(ErrNumberOverflow, fmt.Errorf("%s overflows float64", n))
+	}
+	return f, nil
+}
+
+// BigInt returns the number as *big.Int.
+//
+// Returns ErrNumberIsNotInteger if the number has a fractional part.
+func (n Number) BigInt() (*big.Int, error) {
+	r, err := n.rat()
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsInt() {
+		return nil, errors.Join(ErrNumberIsNotInteger, fmt.Errorf("%s is not integer", n))
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// BigFloat returns the number as *big.Float.
+//
+// The precision of the result is large enough to hold 38 significant digits which is the precision of DynamoDB.
+func (n Number) BigFloat() (*big.Float, error) {
+	if !n.IsValid() {
+		return nil, errors.Join(ErrInvalidNumber, fmt.Errorf("%q is not a number", n))
+	}
+	f, _, err := big.ParseFloat(string(n), 10, numberPrecision, big.ToNearestEven)
+	if err != nil {
+		return nil, errors.Join(ErrNumberOverflow, err)
+	}
+	return f, nil
+}
+
+// numberPrecision is the precision in bits of *big.Float that can hold 38 significant decimal digits.
+const numberPrecision = 128
+
+// rat returns the number as *big.Rat.
+func (n Number) rat() (*big.Rat, error) {
+	if !n.IsValid() {
+		return nil, errors.Join(ErrInvalidNumber, fmt.Errorf("%q is not a number", n))
+	}
+	r, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		return nil, errors.Join(ErrInvalidNumber, fmt.Errorf("%q is not a number", n))
+	}
+	return r, nil
+}
+
+// canonical returns the string that is the same for numbers of the same numeric value.
+func (n Number) canonical() string {
+	r, err := n.rat()
+	if err != nil {
+		return string(n)
+	}
+	return r.RatString()
+}
+
+// compare compares the numbers by its numeric value.
+// Invalid numbers are ordered before valid numbers.
+func (n Number) compare(other Number) int {
+	a, aErr := n.rat()
+	b, bErr := other.rat()
+	switch {
+	case aErr != nil && bErr != nil:
+		return 0
+	case aErr != nil:
+		return -1
+	case bErr != nil:
+		return 1
+	}
+	return a.Cmp(b)
+}
+
+// Scan implements the [sql.Scanner#Scan]
+//
+// [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
+func (n *Number) Scan(value interface{}) error {
+	if isNull(value) {
+		*n = ""
+		return nil
+	}
+	v, err := toNumber(value)
+	if err != nil {
+		return err
+	}
+	*n = v
+	return nil
+}
+
+// decodeWith assigns the number to the number, keeping the precision of *types.AttributeValueMemberN.
+func (n *Number) decodeWith(_ *Decoder, value interface{}) error {
+	return n.Scan(value)
+}
+
+// Value implements the [driver.Valuer] interface.
+//
+// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
+func (n Number) Value() (driver.Value, error) {
+	if !n.IsValid() {
+		return nil, errors.Join(ErrInvalidNumber, fmt.Errorf("%q is not a number", n))
+	}
+	return &types.AttributeValueMemberN{Value: string(n)}, nil
+}
+
+// GormDataType returns the data type for Gorm.
+func (n *Number) GormDataType() string {
+	return "N"
+}
+
+// toNumber converts the value to a Number.
+func toNumber(value interface{}) (Number, error) {
+	var n Number
+	switch value := value.(type) {
+	case Number:
+		n = value
+	case string:
+		n = Number(value)
+	case []byte:
+		n = Number(value)
+	case *types.AttributeValueMemberN:
+		n = Number(value.Value)
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return "", errors.Join(ErrInvalidNumber, fmt.Errorf("%v is not a number", value))
+		}
+		n = Number(strconv.FormatFloat(value, 'g', -1, 64))
+	case float32:
+		if math.IsNaN(float64(value)) || math.IsInf(float64(value), 0) {
+			return "", errors.Join(ErrInvalidNumber, fmt.Errorf("%v is not a number", value))
+		}
+		n = Number(strconv.FormatFloat(float64(value), 'g', -1, 32))
+	case int:
+		n = Number(strconv.FormatInt(int64(value), 10))
+	case int8:
+		n = Number(strconv.FormatInt(int64(value), 10))
+	case int16:
+		n = Number(strconv.FormatInt(int64(value), 10))
+	case int32:
+		n = Number(strconv.FormatInt(int64(value), 10))
+	case int64:
+		n = Number(strconv.FormatInt(value, 10))
+	case uint:
+		n = Number(strconv.FormatUint(uint64(value), 10))
+	case uint8:
+		n = Number(strconv.FormatUint(uint64(value), 10))
+	case uint16:
+		n = Number(strconv.FormatUint(uint64(value), 10))
+	case uint32:
+		n = Number(strconv.FormatUint(uint64(value), 10))
+	case uint64:
+		n = Number(strconv.FormatUint(value, 10))
+	default:
+		return "", errors.Join(ErrValueIsIncompatibleOfNumber, fmt.Errorf("incompatible %T and %T", n, value))
+	}
+	if !n.IsValid() {
+		return "", errors.Join(ErrInvalidNumber, fmt.Errorf("%q is not a number", n))
+	}
+	return n, nil
+}
+
+// scanAsNumberSet scans the value as Set[Number]
+func scanAsNumberSet(s *Set[Number], value interface{}) error {
+	var sv []interface{}
+	switch value := value.(type) {
+	case []Number:
+		*s = append(*s, value...)
+		return nil
+	case *types.AttributeValueMemberNS:
+		for _, v := range value.Value {
+			*s = append(*s, Number(v))
+		}
+		return nil
+	case []string:
+		sv = make([]interface{}, 0, len(value))
+		for _, v := range value {
+			sv = append(sv, v)
+		}
+	case []float64:
+		sv = make([]interface{}, 0, len(value))
+		for _, v := range value {
+			sv = append(sv, v)
+		}
+	case []interface{}:
+		sv = value
+	default:
+		*s = nil
+		return ErrValueIsIncompatibleOfNumberSlice
+	}
+	for _, v := range sv {
+		n, err := toNumber(v)
+		if err != nil {
+			*s = nil
+			return errors.Join(ErrValueIsIncompatibleOfNumberSlice, err)
+		}
+		*s = append(*s, n)
+	}
+	return nil
+}
@@ -0,0 +1,353 @@
+package sqldav
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestNumber_Scan(t *testing.T) {
+	type testCase struct {
+		sut           Number
+		args          interface{}
+		want          error
+		expectedState Number
+	}
+	tests := map[string]testCase{
+		"happy-path/string": {
+			args:          "12345678901234567890123456789012345678",
+			expectedState: "12345678901234567890123456789012345678",
+		},
+		"happy-path/decimal-string": {
+			args:          "0.1",
+			expectedState: "0.1",
+		},
+		"happy-path/attribute-value": {
+			args:          &types.AttributeValueMemberN{Value: "-1.5E+3"},
+			expectedState: "-1.5E+3",
+		},
+		"happy-path/null-attribute-value": {
+			sut:           "1",
+			args:          &types.AttributeValueMemberNULL{Value: true},
+			expectedState: "",
+		},
+		"happy-path/float64": {
+			args:          1.1,
+			expectedState: "1.1",
+		},
+		"happy-path/int64": {
+			args:          int64(math.MaxInt64),
+			expectedState: "9223372036854775807",
+		},
+		"happy-path/uint64": {
+			args:          uint64(math.MaxUint64),
+			expectedState: "18446744073709551615",
+		},
+		"happy-path/null": {
+			sut:           "1",
+			args:          nil,
+			expectedState: "",
+		},
+		"unhappy-path/invalid-string": {
+			args: "foo",
+			want: ErrInvalidNumber,
+		},
+		"unhappy-path/nan": {
+			args: math.NaN(),
+			want: ErrInvalidNumber,
+		},
+		"unhappy-path/incompatible": {
+			args: true,
+			want: ErrValueIsIncompatibleOfNumber,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.sut.Scan(tt.args)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Scan() error = %v, want %v", err, tt.want)
+				return
+			}
+			if diff := cmp.Diff(tt.expectedState, tt.sut); diff != "" {
+				t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNumber_Value(t *testing.T) {
+	type want struct {
+		value interface{}
+		error error
+	}
+	type test struct {
+		sut  Number
+		want want
+	}
+	tests := map[string]test{
+		"happy-path/38-digits": {
+			sut: "1234567890.1234567890123456789012345678",
+			want: want{
+				value: &types.AttributeValueMemberN{Value: "1234567890.1234567890123456789012345678"},
+			},
+		},
+		"happy-path/exponent": {
+			sut: "1e-130",
+			want: want{
+				value: &types.AttributeValueMemberN{Value: "1e-130"},
+			},
+		},
+		"unhappy-path/empty": {
+			sut: "",
+			want: want{
+				error: ErrInvalidNumber,
+			},
+		},
+		"unhappy-path/fraction": {
+			sut: "1/2",
+			want: want{
+				error: ErrInvalidNumber,
+			},
+		},
+	}
+	opts := []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberN{}),
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.sut.Value()
+			if !errors.Is(err, tt.want.error) {
+				t.Errorf("Value() error = %v, want %v", err, tt.want.error)
+			}
+			if diff := cmp.Diff(tt.want.value, got, opts...); diff != "" {
+				t.Errorf("Value() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNumber_Int64(t *testing.T) {
+	type want struct {
+		value int64
+		error error
+	}
+	tests := map[string]struct {
+		sut  Number
+		want want
+	}{
+		"happy-path/max":      {sut: "9223372036854775807", want: want{value: math.MaxInt64}},
+		"happy-path/min":      {sut: "-9223372036854775808", want: want{value: math.MinInt64}},
+		"happy-path/exponent": {sut: "1.5e3", want: want{value: 1500}},
+		"happy-path/trailing-zeros": {
+			sut:  "10.000",
+			want: want{value: 10},
+		},
+		"unhappy-path/overflow":    {sut: "9223372036854775808", want: want{error: ErrNumberOverflow}},
+		"unhappy-path/fraction":    {sut: "1.5", want: want{error: ErrNumberIsNotInteger}},
+		"unhappy-path/not-number":  {sut: "foo", want: want{error: ErrInvalidNumber}},
+		"unhappy-path/38-digits":   {sut: "12345678901234567890123456789012345678", want: want{error: ErrNumberOverflow}},
+		"unhappy-path/hexadecimal": {sut: "0x10", want: want{error: ErrInvalidNumber}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.sut.Int64()
+			if !errors.Is(err, tt.want.error) {
+				t.Errorf("Int64() error = %v, want %v", err, tt.want.error)
+			}
+			if got != tt.want.value {
+				t.Errorf("Int64() = %v, want %v", got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestNumber_Uint64(t *testing.T) {
+	type want struct {
+		value uint64
+		error error
+	}
+	tests := map[string]struct {
+		sut  Number
+		want want
+	}{
+		"happy-path/max":        {sut: "18446744073709551615", want: want{value: math.MaxUint64}},
+		"unhappy-path/overflow": {sut: "18446744073709551616", want: want{error: ErrNumberOverflow}},
+		"unhappy-path/negative": {sut: "-1", want: want{error: ErrNumberOverflow}},
+		"unhappy-path/fraction": {sut: "0.1", want: want{error: ErrNumberIsNotInteger}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.sut.Uint64()
+			if !errors.Is(err, tt.want.error) {
+				t.Errorf("Uint64() error = %v, want %v", err, tt.want.error)
+			}
+			if got != tt.want.value {
+				t.Errorf("Uint64() = %v, want %v", got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestNumber_Float64(t *testing.T) {
+	type want struct {
+		value float64
+		error error
+	}
+	tests := map[string]struct {
+		sut  Number
+		want want
+	}{
+		"happy-path/decimal":    {sut: "0.1", want: want{value: 0.1}},
+		"happy-path/exponent":   {sut: "-1E+125", want: want{value: -1e125}},
+		"unhappy-path/overflow": {sut: "1e400", want: want{error: ErrNumberOverflow}},
+		"unhappy-path/invalid":  {sut: "", want: want{error: ErrInvalidNumber}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.sut.Float64()
+			if !errors.Is(err, tt.want.error) {
+				t.Errorf("Float64() error = %v, want %v", err, tt.want.error)
+			}
+			if got != tt.want.value {
+				t.Errorf("Float64() = %v, want %v", got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestNumber_BigInt(t *testing.T) {
+	got, err := Number("-12345678901234567890123456789012345678").BigInt()
+	if err != nil {
+		t.Fatalf("BigInt() error = %v", err)
+	}
+	want, _ := new(big.Int).SetString("-12345678901234567890123456789012345678", 10)
+	if got.Cmp(want) != 0 {
+		t.Errorf("BigInt() = %v, want %v", got, want)
+	}
+	if _, err := Number("1.5").BigInt(); !errors.Is(err, ErrNumberIsNotInteger) {
+		t.Errorf("BigInt() error = %v, want %v", err, ErrNumberIsNotInteger)
+	}
+}
+
+func TestNumber_BigFloat(t *testing.T) {
+	got, err := Number("1234567890.1234567890123456789012345678").BigFloat()
+	if err != nil {
+		t.Fatalf("BigFloat() error = %v", err)
+	}
+	if s := got.Text('f', 28); s != "1234567890.1234567890123456789012345678" {
+		t.Errorf("BigFloat() = %v, want %v", s, "1234567890.1234567890123456789012345678")
+	}
+	if _, err := Number("foo").BigFloat(); !errors.Is(err, ErrInvalidNumber) {
+		t.Errorf("BigFloat() error = %v, want %v", err, ErrInvalidNumber)
+	}
+}
+
+func TestSet_Scan_Number(t *testing.T) {
+	type testCase struct {
+		sut           Set[Number]
+		args          interface{}
+		want          error
+		expectedState Set[Number]
+	}
+	tests := map[string]testCase{
+		"happy-path/string-slice": {
+			sut:           newSet[Number](),
+			args:          []string{"12345678901234567890123456789012345678", "0.1"},
+			expectedState: Set[Number]{"12345678901234567890123456789012345678", "0.1"},
+		},
+		"happy-path/float64-slice": {
+			sut:           newSet[Number](),
+			args:          []float64{1, 1.1},
+			expectedState: Set[Number]{"1", "1.1"},
+		},
+		"happy-path/attribute-value": {
+			sut:           newSet[Number](),
+			args:          &types.AttributeValueMemberNS{Value: []string{"1", "2"}},
+			expectedState: Set[Number]{"1", "2"},
+		},
+		"happy-path/null": {
+			sut:           newSet[Number](),
+			args:          nil,
+			expectedState: nil,
+		},
+		"unhappy-path/not-slice": {
+			sut:  newSet[Number](),
+			args: 1,
+			want: ErrValueIsIncompatibleOfNumberSlice,
+		},
+		"unhappy-path/invalid-number": {
+			sut:  newSet[Number](),
+			args: []string{"foo"},
+			want: ErrValueIsIncompatibleOfNumberSlice,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.sut.Scan(tt.args)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Scan() error = %v, want %v", err, tt.want)
+				return
+			}
+			if diff := cmp.Diff(tt.expectedState, tt.sut); diff != "" {
+				t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSet_Value_Number(t *testing.T) {
+	type want struct {
+		value *types.AttributeValueMemberNS
+		error error
+	}
+	type test struct {
+		sut  Set[Number]
+		want want
+	}
+	tests := map[string]test{
+		"happy-path/lossless": {
+			sut: Set[Number]{"12345678901234567890123456789012345678", "0.1"},
+			want: want{
+				value: &types.AttributeValueMemberNS{Value: []string{"0.1", "12345678901234567890123456789012345678"}},
+			},
+		},
+		"happy-path/numerically-duplicated": {
+			sut: Set[Number]{"10", "1.0", "1", "1e1"},
+			want: want{
+				value: &types.AttributeValueMemberNS{Value: []string{"1.0", "10"}},
+			},
+		},
+		"unhappy-path/invalid-number": {
+			sut: Set[Number]{"1", "foo"},
+			want: want{
+				error: ErrSetContainsInvalidNumber,
+			},
+		},
+	}
+	opts := []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberNS{}),
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.sut.Value()
+			if !errors.Is(err, tt.want.error) {
+				t.Errorf("Value() error = %v, want %v", err, tt.want.error)
+			}
+			if diff := cmp.Diff(tt.want.value, got, opts...); diff != "" {
+				t.Errorf("Value() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSet_Contains_Number(t *testing.T) {
+	if !(Set[Number]{"1.50", "2"}).Contains("1.5") {
+		t.Errorf("Contains() = false, want true")
+	}
+	if (Set[Number]{"1.50", "2"}).Contains("1.05") {
+		t.Errorf("Contains() = true, want false")
+	}
+}
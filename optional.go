@@ -0,0 +1,164 @@
+package sqldav
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"reflect"
+)
+
+// compatibility check
+var (
+	_ driver.Valuer              = (*Optional[string])(nil)
+	_ sql.Scanner                = (*Optional[string])(nil)
+	_ attributevalue.Marshaler   = (*Optional[string])(nil)
+	_ attributevalue.Unmarshaler = (*Optional[string])(nil)
+)
+
+// OptionalState is the state of an Optional.
+type OptionalState int
+
+const (
+	// OptionalMissing means the attribute is missing. This is the zero value.
+	OptionalMissing OptionalState = iota
+	// OptionalNull means the attribute is NULL.
+	OptionalNull
+	// OptionalPresent means the attribute has a value.
+	OptionalPresent
+)
+
+// String returns the name of the state.
+func (s OptionalState) String() string {
+	switch s {
+	case OptionalMissing:
+		return "missing"
+	case OptionalNull:
+		return "null"
+	case OptionalPresent:
+		return "present"
+	}
+	return "unknown"
+}
+
+// Optional represents an attribute of T that may be missing, NULL or present with a value.
+// It distinguishes the missing attribute from NULL, e.g. for partial updates.
+//
+// As a struct field or a map value, Optional is omitted if it is missing, converted to NULL if it is NULL,
+// and converted from V if it is present. NULL is written even with WithOmitNullAttributes.
+// Elsewhere, such as in lists, the missing Optional is converted to NULL.
+// The AWS SDK's attributevalue package cannot omit it, so it writes NULL for the missing Optional as well.
+//
+// The Decoder leaves the Optional missing if the attribute is missing,
+// so decode into the zero value to tell the missing attribute.
+type Optional[T any] struct {
+	V     T
+	State OptionalState
+}
+
+// OptionalOf returns the Optional present with the value.
+func OptionalOf[T any](v T) Optional[T] {
+	return Optional[T]{V: v, State: OptionalPresent}
+}
+
+// IsMissing reports whether the attribute is missing.
+func (o Optional[T]) IsMissing() bool {
+	return o.State == OptionalMissing
+}
+
+// IsNull reports whether the attribute is NULL.
+func (o Optional[T]) IsNull() bool {
+	return o.State == OptionalNull
+}
+
+// IsPresent reports whether the attribute has a value.
+func (o Optional[T]) IsPresent() bool {
+	return o.State == OptionalPresent
+}
+
+// Get returns V, and reports whether the attribute has a value.
+func (o Optional[T]) Get() (T, bool) {
+	return o.V, o.IsPresent()
+}
+
+// optionalState returns the state of the Optional.
+func (o Optional[T]) optionalState() OptionalState {
+	return o.State
+}
+
+// Scan implements the [sql.Scanner#Scan]
+//
+// NULL sets the state to OptionalNull. Otherwise, the value is assigned to V and the state is set to OptionalPresent.
+//
+// [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
+func (o *Optional[T]) Scan(value interface{}) error {
+	return scanWithDefaultDecoder(o, value)
+}
+
+// decodeWith assigns the value to V with the Decoder, unless the value is NULL.
+func (o *Optional[T]) decodeWith(d *Decoder, value interface{}) error {
+	var v T
+	if isNull(value) {
+		o.V, o.State = v, OptionalNull
+		return nil
+	}
+	rv := reflect.ValueOf(&v).Elem()
+	if err := d.decode(rv.Type(), rv, value); err != nil {
+		return err
+	}
+	o.V, o.State = v, OptionalPresent
+	return nil
+}
+
+// Value implements the [driver.Valuer] interface.
+//
+// Returns *types.AttributeValueMemberNULL unless the state is OptionalPresent.
+//
+// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
+func (o Optional[T]) Value() (driver.Value, error) {
+	return o.encodeWith(defaultEncoder)
+}
+
+// encodeWith converts V with the Encoder, or to NULL unless the state is OptionalPresent.
+// The missing Optional is omitted from structs and maps before it is converted.
+func (o Optional[T]) encodeWith(e *Encoder) (types.AttributeValue, error) {
+	if o.State != OptionalPresent {
+		return newNullAttributeValue(), nil
+	}
+	return e.encode(o.V)
+}
+
+// MarshalDynamoDBAttributeValue implements the [attributevalue.Marshaler] interface.
+//
+// [attributevalue.Marshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Marshaler
+func (o Optional[T]) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return o.encodeWith(defaultEncoder)
+}
+
+// UnmarshalDynamoDBAttributeValue implements the [attributevalue.Unmarshaler] interface.
+//
+// [attributevalue.Unmarshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Unmarshaler
+func (o *Optional[T]) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return o.decodeWith(defaultDecoder.Load(), av)
+}
+
+// GormDataType returns the data type of T for Gorm.
+func (o *Optional[T]) GormDataType() string {
+	if g, ok := interface{}(&o.V).(interface{ GormDataType() string }); ok {
+		return g.GormDataType()
+	}
+	return ""
+}
+
+// optionalAttribute is implemented by Optional to tell its state to the Encoder.
+type optionalAttribute interface {
+	optionalState() OptionalState
+}
+
+// optionalStateOf returns the state of the value if it is an Optional.
+func optionalStateOf(rv reflect.Value) (OptionalState, bool) {
+	if rv.Kind() != reflect.Struct || !rv.Type().Implements(optionalAttributeType) {
+		return 0, false
+	}
+	return rv.Interface().(optionalAttribute).optionalState(), true
+}
@@ -0,0 +1,234 @@
+package sqldav
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestOptional_Scan(t *testing.T) {
+	type test struct {
+		sut           interface{ Scan(interface{}) error }
+		args          interface{}
+		expectedState interface{}
+		want          error
+	}
+	tests := map[string]test{
+		"happy-path/nil": {
+			sut:           &Optional[string]{V: "a", State: OptionalPresent},
+			args:          nil,
+			expectedState: &Optional[string]{State: OptionalNull},
+		},
+		"happy-path/null-attribute-value": {
+			sut:           &Optional[int]{},
+			args:          &types.AttributeValueMemberNULL{Value: true},
+			expectedState: &Optional[int]{State: OptionalNull},
+		},
+		"happy-path/string": {
+			sut:           &Optional[string]{},
+			args:          "a",
+			expectedState: &Optional[string]{V: "a", State: OptionalPresent},
+		},
+		"happy-path/typed-list": {
+			sut:           &Optional[TypedList[int]]{},
+			args:          []interface{}{float64(1)},
+			expectedState: &Optional[TypedList[int]]{V: TypedList[int]{1}, State: OptionalPresent},
+		},
+		"unhappy-path/incompatible": {
+			sut:           &Optional[int]{},
+			args:          "a",
+			expectedState: &Optional[int]{},
+			want:          ErrNestedStructHasIncompatibleAttributes,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.sut.Scan(tt.args)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Scan() error = %v, want %v", err, tt.want)
+				return
+			}
+			if diff := cmp.Diff(tt.expectedState, tt.sut); diff != "" {
+				t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type partialAddress struct {
+	City Optional[string] `dynamodbav:"city"`
+	Zip  Optional[*int]   `dynamodbav:"zip"`
+}
+
+type partialUpdate struct {
+	Name    Optional[string]                    `dynamodbav:"name"`
+	Note    Optional[string]                    `dynamodbav:"note"`
+	Tags    Optional[Set[string]]               `dynamodbav:"tags"`
+	Address partialAddress                      `dynamodbav:"address"`
+	Lines   TypedList[partialAddress]           `dynamodbav:"lines"`
+	Extra   map[string]Optional[string]         `dynamodbav:"extra"`
+	Missing Optional[string]                    `dynamodbav:"missing,omitempty"`
+	Labels  TypedMap[Optional[string]]          `dynamodbav:"labels"`
+	Ignored Optional[TypedMap[string]]          `dynamodbav:"-"`
+	Nested  Optional[Document[*partialAddress]] `dynamodbav:"nested"`
+}
+
+func TestOptional_Struct(t *testing.T) {
+	null := &types.AttributeValueMemberNULL{Value: true}
+	av := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"name": &types.AttributeValueMemberS{Value: "a"},
+		"note": null,
+		"address": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"city": null,
+		}},
+		"lines": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"zip": &types.AttributeValueMemberN{Value: "1"},
+			}},
+			&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"city": &types.AttributeValueMemberS{Value: "b"},
+				"zip":  null,
+			}},
+		}},
+		"extra": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"c": null,
+		}},
+		"labels": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"d": &types.AttributeValueMemberS{Value: "e"},
+		}},
+	}}
+	var got partialUpdate
+	if err := Unmarshal(av, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	one := 1
+	want := partialUpdate{
+		Name:    OptionalOf("a"),
+		Note:    Optional[string]{State: OptionalNull},
+		Address: partialAddress{City: Optional[string]{State: OptionalNull}},
+		Lines: TypedList[partialAddress]{
+			{Zip: OptionalOf(&one)},
+			{City: OptionalOf("b"), Zip: Optional[*int]{State: OptionalNull}},
+		},
+		Extra:  map[string]Optional[string]{"c": {State: OptionalNull}},
+		Labels: TypedMap[Optional[string]]{"d": OptionalOf("e")},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+	}
+
+	got.Extra["f"] = Optional[string]{}
+	got.Labels["g"] = Optional[string]{}
+	encoded, err := NewEncoder(WithOmitNullAttributes()).Encode(got)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if diff := cmp.Diff(av, encoded, attributeValueCmpOptions()...); diff != "" {
+		t.Errorf("Encode() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOptional_Value(t *testing.T) {
+	null := &types.AttributeValueMemberNULL{Value: true}
+	type test struct {
+		sut  Optional[string]
+		want types.AttributeValue
+	}
+	tests := map[string]test{
+		"missing": {
+			sut:  Optional[string]{V: "a"},
+			want: null,
+		},
+		"null": {
+			sut:  Optional[string]{V: "a", State: OptionalNull},
+			want: null,
+		},
+		"present": {
+			sut:  OptionalOf("a"),
+			want: &types.AttributeValueMemberS{Value: "a"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.sut.Value()
+			if err != nil {
+				t.Fatalf("Value() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, attributeValueCmpOptions()...); diff != "" {
+				t.Errorf("Value() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOptional_List(t *testing.T) {
+	got, err := Marshal([]Optional[string]{{}, {State: OptionalNull}, OptionalOf("a")})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := &types.AttributeValueMemberL{Value: []types.AttributeValue{
+		&types.AttributeValueMemberNULL{Value: true},
+		&types.AttributeValueMemberNULL{Value: true},
+		&types.AttributeValueMemberS{Value: "a"},
+	}}
+	if diff := cmp.Diff(want, got, attributeValueCmpOptions()...); diff != "" {
+		t.Errorf("Marshal() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOptional_AttributeValueMarshaler(t *testing.T) {
+	type sdkPartial struct {
+		Name Optional[string] `dynamodbav:"name"`
+		Note Optional[string] `dynamodbav:"note"`
+	}
+	in := sdkPartial{Name: OptionalOf("a"), Note: Optional[string]{State: OptionalNull}}
+	avm, err := attributevalue.MarshalMap(in)
+	if err != nil {
+		t.Fatalf("attributevalue.MarshalMap() error = %v", err)
+	}
+	want := map[string]types.AttributeValue{
+		"name": &types.AttributeValueMemberS{Value: "a"},
+		"note": &types.AttributeValueMemberNULL{Value: true},
+	}
+	if diff := cmp.Diff(want, avm, attributeValueCmpOptions()...); diff != "" {
+		t.Errorf("attributevalue.MarshalMap() mismatch (-want +got):\n%s", diff)
+	}
+
+	var got sdkPartial
+	if err := attributevalue.UnmarshalMap(avm, &got); err != nil {
+		t.Fatalf("attributevalue.UnmarshalMap() error = %v", err)
+	}
+	if diff := cmp.Diff(in, got); diff != "" {
+		t.Errorf("attributevalue.UnmarshalMap() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOptional_DecodeError(t *testing.T) {
+	type target struct {
+		Zip Optional[*int] `dynamodbav:"zip"`
+	}
+	var l TypedList[target]
+	err := l.Scan([]interface{}{map[string]interface{}{"zip": "a"}})
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("Scan() error = %v, want *DecodeError", err)
+	}
+	if de.Path != "[0].zip" {
+		t.Errorf("DecodeError.Path = %v, want [0].zip", de.Path)
+	}
+}
+
+func TestOptionalState_String(t *testing.T) {
+	for state, want := range map[OptionalState]string{
+		OptionalMissing:  "missing",
+		OptionalNull:     "null",
+		OptionalPresent:  "present",
+		OptionalState(9): "unknown",
+	} {
+		if got := state.String(); got != want {
+			t.Errorf("String() = %v, want %v", got, want)
+		}
+	}
+}
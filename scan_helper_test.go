@@ -3,6 +3,7 @@ package sqldav
 import (
 	"database/sql"
 	"errors"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/google/go-cmp/cmp"
 	"reflect"
 	"testing"
@@ -158,15 +159,168 @@ func Test_assignInterfaceValueToReflectValue(t *testing.T) {
 			want:     ErrNestedStructHasIncompatibleAttributes,
 			expected: ([]byte)(nil),
 		},
-		"unhappy_path/non_byte_slice": {
+		"happy_path/string_slice": {
 			args: args{
 				rt:    reflect.TypeOf([]string{}),
 				rv:    reflect.New(reflect.TypeOf([]string{})).Elem(),
 				value: []string{"a"},
 			},
+			want:     nil,
+			expected: []string{"a"},
+		},
+		"happy_path/int_slice_from_list": {
+			args: args{
+				rt:    reflect.TypeOf([]int{}),
+				rv:    reflect.New(reflect.TypeOf([]int{})).Elem(),
+				value: []interface{}{float64(1), float64(2)},
+			},
+			want:     nil,
+			expected: []int{1, 2},
+		},
+		"happy_path/uint16_slice_from_number_set": {
+			args: args{
+				rt:    reflect.TypeOf([]uint16{}),
+				rv:    reflect.New(reflect.TypeOf([]uint16{})).Elem(),
+				value: &types.AttributeValueMemberNS{Value: []string{"1", "65535"}},
+			},
+			want:     nil,
+			expected: []uint16{1, 65535},
+		},
+		"happy_path/array": {
+			args: args{
+				rt:    reflect.TypeOf([3]string{}),
+				rv:    reflect.New(reflect.TypeOf([3]string{})).Elem(),
+				value: []interface{}{"a", "b"},
+			},
+			want:     nil,
+			expected: [3]string{"a", "b", ""},
+		},
+		"happy_path/byte_array": {
+			args: args{
+				rt:    reflect.TypeOf([2]byte{}),
+				rv:    reflect.New(reflect.TypeOf([2]byte{})).Elem(),
+				value: []byte("ab"),
+			},
+			want:     nil,
+			expected: [2]byte{'a', 'b'},
+		},
+		"happy_path/map": {
+			args: args{
+				rt:    reflect.TypeOf(map[string]int64{}),
+				rv:    reflect.New(reflect.TypeOf(map[string]int64{})).Elem(),
+				value: map[string]interface{}{"a": float64(1)},
+			},
+			want:     nil,
+			expected: map[string]int64{"a": 1},
+		},
+		"happy_path/int8": {
+			args: args{
+				rt:    reflect.TypeOf(int8(0)),
+				rv:    reflect.New(reflect.TypeOf(int8(0))).Elem(),
+				value: &types.AttributeValueMemberN{Value: "-128"},
+			},
+			want:     nil,
+			expected: int8(-128),
+		},
+		"happy_path/uint64": {
+			args: args{
+				rt:    reflect.TypeOf(uint64(0)),
+				rv:    reflect.New(reflect.TypeOf(uint64(0))).Elem(),
+				value: &types.AttributeValueMemberN{Value: "18446744073709551615"},
+			},
+			want:     nil,
+			expected: uint64(18446744073709551615),
+		},
+		"happy_path/float32": {
+			args: args{
+				rt:    reflect.TypeOf(float32(0)),
+				rv:    reflect.New(reflect.TypeOf(float32(0))).Elem(),
+				value: float64(1.5),
+			},
+			want:     nil,
+			expected: float32(1.5),
+		},
+		"happy_path/interface": {
+			args: args{
+				rt:    reflect.TypeOf((*interface{})(nil)).Elem(),
+				rv:    reflect.New(reflect.TypeOf((*interface{})(nil)).Elem()).Elem(),
+				value: &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberS{Value: "a"}}},
+			},
+			want:     nil,
+			expected: []interface{}{"a"},
+		},
+		"unhappy_path/incompatible_with_string_slice": {
+			args: args{
+				rt:    reflect.TypeOf([]string{}),
+				rv:    reflect.New(reflect.TypeOf([]string{})).Elem(),
+				value: "a",
+			},
 			want:     ErrNestedStructHasIncompatibleAttributes,
 			expected: ([]string)(nil),
 		},
+		"unhappy_path/array_overflow": {
+			args: args{
+				rt:    reflect.TypeOf([1]string{}),
+				rv:    reflect.New(reflect.TypeOf([1]string{})).Elem(),
+				value: []interface{}{"a", "b"},
+			},
+			want:     ErrNestedStructHasIncompatibleAttributes,
+			expected: [1]string{},
+		},
+		"unhappy_path/int8_overflow": {
+			args: args{
+				rt:    reflect.TypeOf(int8(0)),
+				rv:    reflect.New(reflect.TypeOf(int8(0))).Elem(),
+				value: float64(128),
+			},
+			want:     ErrNumberOverflow,
+			expected: int8(0),
+		},
+		"unhappy_path/negative_uint": {
+			args: args{
+				rt:    reflect.TypeOf(uint(0)),
+				rv:    reflect.New(reflect.TypeOf(uint(0))).Elem(),
+				value: float64(-1),
+			},
+			want:     ErrNumberOverflow,
+			expected: uint(0),
+		},
+		"unhappy_path/fraction": {
+			args: args{
+				rt:    reflect.TypeOf(int(0)),
+				rv:    reflect.New(reflect.TypeOf(int(0))).Elem(),
+				value: float64(1.5),
+			},
+			want:     ErrNumberIsNotInteger,
+			expected: 0,
+		},
+		"unhappy_path/float32_overflow": {
+			args: args{
+				rt:    reflect.TypeOf(float32(0)),
+				rv:    reflect.New(reflect.TypeOf(float32(0))).Elem(),
+				value: &types.AttributeValueMemberN{Value: "1e39"},
+			},
+			want:     ErrNumberOverflow,
+			expected: float32(0),
+		},
+		"unhappy_path/incompatible_with_pointer": {
+			args: args{
+				rt:    reflect.TypeOf((*int)(nil)),
+				rv:    reflect.New(reflect.TypeOf((*int)(nil))).Elem(),
+				value: "a",
+			},
+			want:     ErrNestedStructHasIncompatibleAttributes,
+			expected: (*int)(nil),
+		},
+		"unhappy_path/unsupported_type": {
+			args: args{
+				rt:    reflect.TypeOf((chan int)(nil)),
+				rv:    reflect.New(reflect.TypeOf((chan int)(nil))).Elem(),
+				value: "a",
+			},
+			want:     ErrUnsupportedType,
+			expected: (chan int)(nil),
+		},
 		"unhappy_path/incompatible_with_struct": {
 			args: args{
 				rt:    reflect.TypeOf(A{}),
@@ -0,0 +1,123 @@
+package sqldav
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// Contains reports whether the set contains the given element.
+func (s Set[T]) Contains(v T) bool {
+	key := setElementKey(v)
+	for _, e := range s {
+		if setElementKey(e) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Add adds the given elements to the set.
+// Elements that the set already contains are ignored.
+func (s *Set[T]) Add(v ...T) {
+	seen := s.keys()
+	for _, e := range v {
+		key := setElementKey(e)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		*s = append(*s, e)
+	}
+}
+
+// Remove removes the given elements from the set.
+func (s *Set[T]) Remove(v ...T) {
+	if len(*s) == 0 || len(v) == 0 {
+		return
+	}
+	removes := Set[T](v).keys()
+	result := (*s)[:0]
+	for _, e := range *s {
+		if _, ok := removes[setElementKey(e)]; ok {
+			continue
+		}
+		result = append(result, e)
+	}
+	clear((*s)[len(result):])
+	*s = result
+}
+
+// Union returns a new set that contains the elements of both s and other.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	result := make(Set[T], 0, len(s)+len(other))
+	result.Add(s...)
+	result.Add(other...)
+	return result
+}
+
+// Intersect returns a new set that contains the elements that are in both s and other.
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	keys := other.keys()
+	result := make(Set[T], 0, min(len(s), len(other)))
+	for _, e := range s {
+		if _, ok := keys[setElementKey(e)]; ok {
+			result.Add(e)
+		}
+	}
+	return result
+}
+
+// Difference returns a new set that contains the elements of s that are not in other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	keys := other.keys()
+	result := make(Set[T], 0, len(s))
+	for _, e := range s {
+		if _, ok := keys[setElementKey(e)]; !ok {
+			result.Add(e)
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns a new set that contains the elements that are in either s or other, but not in both.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	result := s.Difference(other)
+	result.Add(other.Difference(s)...)
+	return result
+}
+
+// keys returns the keys of the elements in the set.
+func (s Set[T]) keys() map[string]struct{} {
+	keys := make(map[string]struct{}, len(s))
+	for _, e := range s {
+		keys[setElementKey(e)] = struct{}{}
+	}
+	return keys
+}
+
+// setElementKey returns the key that identifies the element in the set.
+//
+// []byte is compared by its content, and numbers are compared by its numeric value as DynamoDB does.
+func setElementKey[T SetSupportable](v T) string {
+	rv := reflect.ValueOf(v)
+	switch setElementKindOf[T]() {
+	case setElementKindString:
+		return rv.String()
+	case setElementKindBinary:
+		return string(rv.Bytes())
+	case setElementKindInt:
+		return strconv.FormatInt(rv.Int(), 10)
+	case setElementKindUint:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case setElementKindFloat:
+		f := rv.Float()
+		if f == 0 {
+			// NOTE: DynamoDB does not distinguish between -0 and 0.
+			f = 0
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	case setElementKindNumber:
+		return Number(rv.String()).canonical()
+	}
+	return ""
+}
@@ -0,0 +1,212 @@
+package sqldav
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSet_Contains(t *testing.T) {
+	type testCase struct {
+		sut  func() bool
+		want bool
+	}
+	tests := map[string]testCase{
+		"happy-path/string": {
+			sut:  func() bool { return Set[string]{"a", "b"}.Contains("b") },
+			want: true,
+		},
+		"happy-path/binary": {
+			sut:  func() bool { return Set[[]byte]{[]byte("a"), []byte("b")}.Contains([]byte("b")) },
+			want: true,
+		},
+		"happy-path/int": {
+			sut:  func() bool { return Set[int]{1, 2}.Contains(2) },
+			want: true,
+		},
+		"happy-path/float64": {
+			sut:  func() bool { return Set[float64]{1.1, 2.2}.Contains(2.2) },
+			want: true,
+		},
+		"happy-path/float64-negative-zero": {
+			sut:  func() bool { return Set[float64]{0}.Contains(math.Copysign(0, -1)) },
+			want: true,
+		},
+		"unhappy-path/string": {
+			sut:  func() bool { return Set[string]{"a", "b"}.Contains("c") },
+			want: false,
+		},
+		"unhappy-path/binary": {
+			sut:  func() bool { return Set[[]byte]{[]byte("a")}.Contains([]byte("c")) },
+			want: false,
+		},
+		"unhappy-path/empty": {
+			sut:  func() bool { return Set[int]{}.Contains(1) },
+			want: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.sut(); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSet_Add(t *testing.T) {
+	type testCase struct {
+		sut           Set[string]
+		args          []string
+		expectedState Set[string]
+	}
+	tests := map[string]testCase{
+		"happy-path/new-elements": {
+			sut:           Set[string]{"a"},
+			args:          []string{"b", "c"},
+			expectedState: Set[string]{"a", "b", "c"},
+		},
+		"happy-path/existing-elements": {
+			sut:           Set[string]{"a", "b"},
+			args:          []string{"b", "c", "c"},
+			expectedState: Set[string]{"a", "b", "c"},
+		},
+		"happy-path/nil-set": {
+			sut:           nil,
+			args:          []string{"a"},
+			expectedState: Set[string]{"a"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.sut.Add(tt.args...)
+			if diff := cmp.Diff(tt.expectedState, tt.sut); diff != "" {
+				t.Errorf("Add() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSet_Add_Binary(t *testing.T) {
+	sut := Set[[]byte]{[]byte("a")}
+	sut.Add([]byte("a"), []byte("b"))
+	if diff := cmp.Diff(Set[[]byte]{[]byte("a"), []byte("b")}, sut); diff != "" {
+		t.Errorf("Add() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSet_Remove(t *testing.T) {
+	type testCase struct {
+		sut           Set[int]
+		args          []int
+		expectedState Set[int]
+	}
+	tests := map[string]testCase{
+		"happy-path/existing-elements": {
+			sut:           Set[int]{1, 2, 3},
+			args:          []int{1, 3},
+			expectedState: Set[int]{2},
+		},
+		"happy-path/missing-elements": {
+			sut:           Set[int]{1, 2, 3},
+			args:          []int{4},
+			expectedState: Set[int]{1, 2, 3},
+		},
+		"happy-path/all-elements": {
+			sut:           Set[int]{1, 2},
+			args:          []int{1, 2},
+			expectedState: Set[int]{},
+		},
+		"happy-path/nil-set": {
+			sut:           nil,
+			args:          []int{1},
+			expectedState: nil,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.sut.Remove(tt.args...)
+			if diff := cmp.Diff(tt.expectedState, tt.sut); diff != "" {
+				t.Errorf("Remove() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSet_Algebra(t *testing.T) {
+	type testCase struct {
+		sut   Set[string]
+		other Set[string]
+		op    func(s, other Set[string]) Set[string]
+		want  Set[string]
+	}
+	tests := map[string]testCase{
+		"union": {
+			sut:   Set[string]{"a", "b"},
+			other: Set[string]{"b", "c"},
+			op:    Set[string].Union,
+			want:  Set[string]{"a", "b", "c"},
+		},
+		"union/nil": {
+			sut:   nil,
+			other: Set[string]{"a"},
+			op:    Set[string].Union,
+			want:  Set[string]{"a"},
+		},
+		"intersect": {
+			sut:   Set[string]{"a", "b", "c"},
+			other: Set[string]{"c", "b", "d"},
+			op:    Set[string].Intersect,
+			want:  Set[string]{"b", "c"},
+		},
+		"intersect/disjoint": {
+			sut:   Set[string]{"a"},
+			other: Set[string]{"b"},
+			op:    Set[string].Intersect,
+			want:  Set[string]{},
+		},
+		"difference": {
+			sut:   Set[string]{"a", "b", "c"},
+			other: Set[string]{"b"},
+			op:    Set[string].Difference,
+			want:  Set[string]{"a", "c"},
+		},
+		"symmetric-difference": {
+			sut:   Set[string]{"a", "b", "c"},
+			other: Set[string]{"b", "c", "d"},
+			op:    Set[string].SymmetricDifference,
+			want:  Set[string]{"a", "d"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tt.op(tt.sut, tt.other)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSet_Algebra_Binary(t *testing.T) {
+	s := Set[[]byte]{[]byte("a"), []byte("b")}
+	other := Set[[]byte]{[]byte("b"), []byte("c")}
+	if diff := cmp.Diff(Set[[]byte]{[]byte("b")}, s.Intersect(other)); diff != "" {
+		t.Errorf("Intersect() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(Set[[]byte]{[]byte("a"), []byte("b"), []byte("c")}, s.Union(other)); diff != "" {
+		t.Errorf("Union() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSet_Algebra_Float64(t *testing.T) {
+	s := Set[float64]{1, 2.5}
+	other := Set[float64]{1.0, 3}
+	if diff := cmp.Diff(Set[float64]{1}, s.Intersect(other)); diff != "" {
+		t.Errorf("Intersect() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(Set[float64]{2.5, 3}, s.SymmetricDifference(other)); diff != "" {
+		t.Errorf("SymmetricDifference() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -1,9 +1,11 @@
 package sqldav
 
 import (
+	"database/sql/driver"
 	"errors"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/google/go-cmp/cmp"
+	"math"
 	"testing"
 )
 
@@ -467,6 +469,30 @@ func TestSet_Value_String(t *testing.T) {
 				value: &types.AttributeValueMemberSS{Value: []string{"1"}},
 			},
 		},
+		"happy-path/duplicated-and-unsorted": {
+			sut: Set[string]{"b", "a", "b", "c"},
+			want: want{
+				value: &types.AttributeValueMemberSS{Value: []string{"a", "b", "c"}},
+			},
+		},
+		"unhappy-path/empty": {
+			sut: Set[string]{},
+			want: want{
+				error: ErrSetIsEmpty,
+			},
+		},
+		"unhappy-path/nil": {
+			sut: nil,
+			want: want{
+				error: ErrSetIsEmpty,
+			},
+		},
+		"unhappy-path/empty-string": {
+			sut: Set[string]{"a", ""},
+			want: want{
+				error: ErrSetContainsEmptyString,
+			},
+		},
 	}
 	opts := []cmp.Option{
 		cmp.AllowUnexported(types.AttributeValueMemberS{}),
@@ -507,6 +533,18 @@ func TestSet_Value_Int(t *testing.T) {
 				value: &types.AttributeValueMemberNS{Value: []string{"1"}},
 			},
 		},
+		"happy-path/duplicated-and-unsorted": {
+			sut: Set[int]{10, 2, 10, -1},
+			want: want{
+				value: &types.AttributeValueMemberNS{Value: []string{"-1", "2", "10"}},
+			},
+		},
+		"unhappy-path/empty": {
+			sut: Set[int]{},
+			want: want{
+				error: ErrSetIsEmpty,
+			},
+		},
 	}
 	opts := []cmp.Option{
 		cmp.AllowUnexported(types.AttributeValueMemberS{}),
@@ -547,6 +585,24 @@ func TestSet_Value_Float64(t *testing.T) {
 				value: &types.AttributeValueMemberNS{Value: []string{"1.1"}},
 			},
 		},
+		"happy-path/duplicated-and-unsorted": {
+			sut: Set[float64]{10.5, 2, 10.5, 1e-3},
+			want: want{
+				value: &types.AttributeValueMemberNS{Value: []string{"0.001", "2", "10.5"}},
+			},
+		},
+		"unhappy-path/nan": {
+			sut: Set[float64]{math.NaN()},
+			want: want{
+				error: ErrSetContainsInvalidNumber,
+			},
+		},
+		"unhappy-path/inf": {
+			sut: Set[float64]{math.Inf(1)},
+			want: want{
+				error: ErrSetContainsInvalidNumber,
+			},
+		},
 	}
 	opts := []cmp.Option{
 		cmp.AllowUnexported(types.AttributeValueMemberS{}),
@@ -587,6 +643,18 @@ func TestSet_Value_Binary(t *testing.T) {
 				value: &types.AttributeValueMemberBS{Value: [][]byte{[]byte("A")}},
 			},
 		},
+		"happy-path/duplicated-and-unsorted": {
+			sut: Set[[]byte]{[]byte("B"), []byte("A"), []byte("B")},
+			want: want{
+				value: &types.AttributeValueMemberBS{Value: [][]byte{[]byte("A"), []byte("B")}},
+			},
+		},
+		"unhappy-path/empty-binary": {
+			sut: Set[[]byte]{[]byte("A"), {}},
+			want: want{
+				error: ErrSetContainsEmptyBinary,
+			},
+		},
 	}
 	opts := []cmp.Option{
 		cmp.AllowUnexported(types.AttributeValueMemberS{}),
@@ -610,3 +678,325 @@ func TestSet_Value_Binary(t *testing.T) {
 		})
 	}
 }
+
+func TestSet_NullIfEmpty(t *testing.T) {
+	type want struct {
+		value interface{}
+		error error
+	}
+	type test struct {
+		sut  Set[string]
+		want want
+	}
+	tests := map[string]test{
+		"happy-path/empty": {
+			sut: Set[string]{},
+			want: want{
+				value: &types.AttributeValueMemberNULL{Value: true},
+			},
+		},
+		"happy-path/nil": {
+			sut: nil,
+			want: want{
+				value: &types.AttributeValueMemberNULL{Value: true},
+			},
+		},
+		"happy-path/not-empty": {
+			sut: Set[string]{"b", "a"},
+			want: want{
+				value: &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+			},
+		},
+		"unhappy-path/empty-string": {
+			sut: Set[string]{""},
+			want: want{
+				value: (*types.AttributeValueMemberSS)(nil),
+				error: ErrSetContainsEmptyString,
+			},
+		},
+	}
+	opts := []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberSS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberNULL{}),
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.sut.NullIfEmpty().Value()
+			if !errors.Is(err, tt.want.error) {
+				t.Errorf("Value() error = %v, want %v", err, tt.want.error)
+			}
+			if diff := cmp.Diff(tt.want.value, got, opts...); diff != "" {
+				t.Errorf("Value() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type role string
+
+type payload []byte
+
+func TestSet_Scan_Widened(t *testing.T) {
+	type testCase struct {
+		sut           interface{ Scan(interface{}) error }
+		args          interface{}
+		want          error
+		expectedState interface{}
+	}
+	tests := map[string]testCase{
+		"happy-path/int64": {
+			sut:           &Set[int64]{},
+			args:          []float64{1, -2},
+			expectedState: &Set[int64]{1, -2},
+		},
+		"happy-path/int64-from-string-without-loss": {
+			sut:           &Set[int64]{},
+			args:          []string{"9223372036854775807"},
+			expectedState: &Set[int64]{math.MaxInt64},
+		},
+		"happy-path/uint32": {
+			sut:           &Set[uint32]{},
+			args:          []float64{math.MaxUint32},
+			expectedState: &Set[uint32]{math.MaxUint32},
+		},
+		"happy-path/float32": {
+			sut:           &Set[float32]{},
+			args:          []float64{1.5},
+			expectedState: &Set[float32]{1.5},
+		},
+		"happy-path/float32-decimal": {
+			sut:           &Set[float32]{},
+			args:          []float64{0.1, float64(float32(1.1))},
+			expectedState: &Set[float32]{0.1, 1.1},
+		},
+		"happy-path/float32-from-number": {
+			sut:           &Set[float32]{},
+			args:          []Number{"0.1"},
+			expectedState: &Set[float32]{0.1},
+		},
+		"happy-path/named-string": {
+			sut:           &Set[role]{},
+			args:          []string{"admin", "member"},
+			expectedState: &Set[role]{"admin", "member"},
+		},
+		"happy-path/named-binary": {
+			sut:           &Set[payload]{},
+			args:          [][]byte{[]byte("a")},
+			expectedState: &Set[payload]{payload("a")},
+		},
+		"unhappy-path/int8-overflow": {
+			sut:           &Set[int8]{},
+			args:          []float64{128},
+			want:          ErrValueOverflowsSetElement,
+			expectedState: (*Set[int8])(new(Set[int8])),
+		},
+		"unhappy-path/uint-negative": {
+			sut:           &Set[uint]{},
+			args:          []float64{-1},
+			want:          ErrValueOverflowsSetElement,
+			expectedState: (*Set[uint])(new(Set[uint])),
+		},
+		"unhappy-path/int16-fraction": {
+			sut:           &Set[int16]{},
+			args:          []float64{1.5},
+			want:          ErrValueIsIncompatibleOfIntSlice,
+			expectedState: (*Set[int16])(new(Set[int16])),
+		},
+		"unhappy-path/float32-overflow": {
+			sut:           &Set[float32]{},
+			args:          []float64{math.MaxFloat64},
+			want:          ErrValueOverflowsSetElement,
+			expectedState: (*Set[float32])(new(Set[float32])),
+		},
+		"unhappy-path/float32-underflow": {
+			sut:           &Set[float32]{},
+			args:          []float64{1e-50},
+			want:          ErrValueLosesPrecisionOfSetElement,
+			expectedState: (*Set[float32])(new(Set[float32])),
+		},
+		"unhappy-path/float32-precision": {
+			sut:           &Set[float32]{},
+			args:          []float64{0.123456789},
+			want:          ErrValueLosesPrecisionOfSetElement,
+			expectedState: (*Set[float32])(new(Set[float32])),
+		},
+		"unhappy-path/float32-precision-from-number": {
+			sut:           &Set[float32]{},
+			args:          []Number{"0.123456789"},
+			want:          ErrValueLosesPrecisionOfSetElement,
+			expectedState: (*Set[float32])(new(Set[float32])),
+		},
+		"unhappy-path/named-string-incompatible": {
+			sut:           &Set[role]{},
+			args:          []float64{1},
+			want:          ErrValueIsIncompatibleOfStringSlice,
+			expectedState: (*Set[role])(new(Set[role])),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.sut.Scan(tt.args)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Scan() error = %v, want %v", err, tt.want)
+				return
+			}
+			if diff := cmp.Diff(tt.expectedState, tt.sut); diff != "" {
+				t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+				return
+			}
+		})
+	}
+}
+
+func TestSet_Value_Widened(t *testing.T) {
+	type want struct {
+		value interface{}
+		error error
+	}
+	type test struct {
+		sut  interface{ Value() (driver.Value, error) }
+		want want
+	}
+	tests := map[string]test{
+		"happy-path/int64": {
+			sut: Set[int64]{math.MaxInt64, math.MinInt64},
+			want: want{
+				value: &types.AttributeValueMemberNS{Value: []string{"-9223372036854775808", "9223372036854775807"}},
+			},
+		},
+		"happy-path/uint64": {
+			sut: Set[uint64]{math.MaxUint64, 0},
+			want: want{
+				value: &types.AttributeValueMemberNS{Value: []string{"0", "18446744073709551615"}},
+			},
+		},
+		"happy-path/float32": {
+			sut: Set[float32]{1.1, 0.5},
+			want: want{
+				value: &types.AttributeValueMemberNS{Value: []string{"0.5", "1.1"}},
+			},
+		},
+		"happy-path/named-string": {
+			sut: Set[role]{"member", "admin"},
+			want: want{
+				value: &types.AttributeValueMemberSS{Value: []string{"admin", "member"}},
+			},
+		},
+		"happy-path/named-binary": {
+			sut: Set[payload]{payload("b"), payload("a")},
+			want: want{
+				value: &types.AttributeValueMemberBS{Value: [][]byte{[]byte("a"), []byte("b")}},
+			},
+		},
+		"unhappy-path/named-string-empty": {
+			sut: Set[role]{""},
+			want: want{
+				value: (*types.AttributeValueMemberSS)(nil),
+				error: ErrSetContainsEmptyString,
+			},
+		},
+	}
+	opts := []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberSS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberNS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberBS{}),
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.sut.Value()
+			if !errors.Is(err, tt.want.error) {
+				t.Errorf("Value() error = %v, want %v", err, tt.want.error)
+			}
+			if diff := cmp.Diff(tt.want.value, got, opts...); diff != "" {
+				t.Errorf("Value() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSet_GormDataType_Widened(t *testing.T) {
+	tests := map[string]struct {
+		sut  interface{ GormDataType() string }
+		want string
+	}{
+		"int8":         {sut: &Set[int8]{}, want: "NS"},
+		"uint64":       {sut: &Set[uint64]{}, want: "NS"},
+		"float32":      {sut: &Set[float32]{}, want: "NS"},
+		"number":       {sut: &Set[Number]{}, want: "NS"},
+		"named-string": {sut: &Set[role]{}, want: "SS"},
+		"named-binary": {sut: &Set[payload]{}, want: "BS"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.sut.GormDataType(); got != tt.want {
+				t.Errorf("GormDataType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSet_IsCompatibleWithSet_Widened(t *testing.T) {
+	tests := map[string]struct {
+		got  bool
+		want bool
+	}{
+		"int64/float64-slice":    {got: isCompatibleWithSet[int64]([]float64{1}), want: true},
+		"int8/overflow":          {got: isCompatibleWithSet[int8]([]float64{1000}), want: false},
+		"uint/negative":          {got: isCompatibleWithSet[uint]([]float64{-1}), want: false},
+		"float32/float64-slice":  {got: isCompatibleWithSet[float32]([]float64{1.1}), want: true},
+		"named-string/same-type": {got: isCompatibleWithSet[role]([]role{"a"}), want: true},
+		"named-string/string":    {got: isCompatibleWithSet[role]([]string{"a"}), want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("isCompatibleWithSet() = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSet_Scan_AttributeValue(t *testing.T) {
+	t.Run("string-set", func(t *testing.T) {
+		var got Set[string]
+		if err := got.Scan(&types.AttributeValueMemberSS{Value: []string{"a", "b"}}); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		if diff := cmp.Diff(Set[string]{"a", "b"}, got); diff != "" {
+			t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+		}
+	})
+	t.Run("number-set-without-loss-of-precision", func(t *testing.T) {
+		var got Set[uint64]
+		if err := got.Scan(&types.AttributeValueMemberNS{Value: []string{"18446744073709551615"}}); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		if diff := cmp.Diff(Set[uint64]{math.MaxUint64}, got); diff != "" {
+			t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+		}
+	})
+	t.Run("binary-set", func(t *testing.T) {
+		var got Set[[]byte]
+		if err := got.Scan(&types.AttributeValueMemberBS{Value: [][]byte{[]byte("a")}}); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		if diff := cmp.Diff(Set[[]byte]{[]byte("a")}, got); diff != "" {
+			t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+		}
+	})
+	t.Run("null", func(t *testing.T) {
+		var got Set[string]
+		if err := got.Scan(&types.AttributeValueMemberNULL{Value: true}); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		if got != nil {
+			t.Errorf("Scan() = %v, want nil", got)
+		}
+	})
+	t.Run("incompatible", func(t *testing.T) {
+		var got Set[int]
+		if err := got.Scan(&types.AttributeValueMemberSS{Value: []string{"a"}}); !errors.Is(err, ErrValueIsIncompatibleOfIntSlice) {
+			t.Errorf("Scan() error = %v, want %v", err, ErrValueIsIncompatibleOfIntSlice)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+// Package streams decodes the attribute values of DynamoDB Streams into the sqldav types and structs.
+//
+// The values are decoded in the same way as the values from the database/sql driver,
+// so the code that reads stream images matches the code that reads PartiQL rows.
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	streamstypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/miyamo2/sqldav"
+)
+
+// ErrMissingImage occurs when the record has no image to decode,
+// e.g. NewImage of a REMOVE event, or OldImage of a stream view type without it.
+var ErrMissingImage = errors.New("record has no image")
+
+// Decoder decodes the attribute values of DynamoDB Streams with a sqldav.Decoder.
+type Decoder struct {
+	decoder *sqldav.Decoder
+}
+
+// NewDecoder returns a new Decoder with the options of sqldav.NewDecoder.
+func NewDecoder(opts ...sqldav.DecoderOption) *Decoder {
+	return &Decoder{decoder: sqldav.NewDecoder(opts...)}
+}
+
+// defaultDecoder decodes with the default sqldav.Decoder, which is set by sqldav.SetDefaultDecoderOptions.
+var defaultDecoder = &Decoder{}
+
+// Unmarshal converts the attribute value of DynamoDB Streams to the Go value pointed to by v
+// with the default sqldav.Decoder.
+func Unmarshal(av streamstypes.AttributeValue, v interface{}) error {
+	return defaultDecoder.Decode(av, v)
+}
+
+// UnmarshalImage converts the image of DynamoDB Streams to the Go value pointed to by v
+// with the default sqldav.Decoder.
+func UnmarshalImage(image map[string]streamstypes.AttributeValue, v interface{}) error {
+	return defaultDecoder.DecodeImage(image, v)
+}
+
+// UnmarshalNewImage converts the NewImage of the record to the Go value pointed to by v
+// with the default sqldav.Decoder.
+func UnmarshalNewImage(r streamstypes.Record, v interface{}) error {
+	return defaultDecoder.DecodeNewImage(r, v)
+}
+
+// UnmarshalOldImage converts the OldImage of the record to the Go value pointed to by v
+// with the default sqldav.Decoder.
+func UnmarshalOldImage(r streamstypes.Record, v interface{}) error {
+	return defaultDecoder.DecodeOldImage(r, v)
+}
+
+// UnmarshalKeys converts the Keys of the record to the Go value pointed to by v
+// with the default sqldav.Decoder.
+func UnmarshalKeys(r streamstypes.Record, v interface{}) error {
+	return defaultDecoder.DecodeKeys(r, v)
+}
+
+// Decode converts the attribute value of DynamoDB Streams to the Go value pointed to by v,
+// such as sqldav.Map, sqldav.TypedList[T], sqldav.Set[T] and structs.
+func (d *Decoder) Decode(av streamstypes.AttributeValue, v interface{}) error {
+	dav, err := attributevalue.FromDynamoDBStreams(av)
+	if err != nil {
+		return err
+	}
+	return d.decode(dav, v)
+}
+
+// DecodeImage converts the image of DynamoDB Streams to the Go value pointed to by v,
+// such as sqldav.Map, sqldav.TypedMap[V] and structs.
+func (d *Decoder) DecodeImage(image map[string]streamstypes.AttributeValue, v interface{}) error {
+	m, err := attributevalue.FromDynamoDBStreamsMap(image)
+	if err != nil {
+		return err
+	}
+	return d.decode(&types.AttributeValueMemberM{Value: m}, v)
+}
+
+// DecodeNewImage converts the NewImage of the record to the Go value pointed to by v.
+// Returns ErrMissingImage if the record has no NewImage.
+func (d *Decoder) DecodeNewImage(r streamstypes.Record, v interface{}) error {
+	if r.Dynamodb == nil || r.Dynamodb.NewImage == nil {
+		return errors.Join(ErrMissingImage, fmt.Errorf("%s event has no NewImage", r.EventName))
+	}
+	return d.DecodeImage(r.Dynamodb.NewImage, v)
+}
+
+// DecodeOldImage converts the OldImage of the record to the Go value pointed to by v.
+// Returns ErrMissingImage if the record has no OldImage.
+func (d *Decoder) DecodeOldImage(r streamstypes.Record, v interface{}) error {
+	if r.Dynamodb == nil || r.Dynamodb.OldImage == nil {
+		return errors.Join(ErrMissingImage, fmt.Errorf("%s event has no OldImage", r.EventName))
+	}
+	return d.DecodeImage(r.Dynamodb.OldImage, v)
+}
+
+// DecodeKeys converts the Keys of the record to the Go value pointed to by v.
+// Returns ErrMissingImage if the record has no Keys.
+func (d *Decoder) DecodeKeys(r streamstypes.Record, v interface{}) error {
+	if r.Dynamodb == nil || r.Dynamodb.Keys == nil {
+		return errors.Join(ErrMissingImage, fmt.Errorf("%s event has no Keys", r.EventName))
+	}
+	return d.DecodeImage(r.Dynamodb.Keys, v)
+}
+
+// decode converts the types.AttributeValue with the sqldav.Decoder, or the default one if it is not set.
+func (d *Decoder) decode(av types.AttributeValue, v interface{}) error {
+	if d.decoder == nil {
+		return sqldav.Unmarshal(av, v)
+	}
+	return d.decoder.Decode(av, v)
+}
@@ -0,0 +1,153 @@
+package streams
+
+import (
+	"errors"
+	streamstypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/miyamo2/sqldav"
+	"testing"
+)
+
+type Item struct {
+	Name string `dynamodbav:"name"`
+}
+
+type User struct {
+	ID    string                  `dynamodbav:"id"`
+	Age   int                     `dynamodbav:"age"`
+	Tags  sqldav.Set[string]      `dynamodbav:"tags"`
+	Items sqldav.TypedList[Item]  `dynamodbav:"items"`
+	Attrs sqldav.Map              `dynamodbav:"attrs"`
+	Score sqldav.Number           `dynamodbav:"score"`
+	Raw   sqldav.TypedMap[[]byte] `dynamodbav:"raw"`
+}
+
+func image() map[string]streamstypes.AttributeValue {
+	return map[string]streamstypes.AttributeValue{
+		"id":   &streamstypes.AttributeValueMemberS{Value: "u1"},
+		"age":  &streamstypes.AttributeValueMemberN{Value: "30"},
+		"tags": &streamstypes.AttributeValueMemberSS{Value: []string{"a", "b"}},
+		"items": &streamstypes.AttributeValueMemberL{Value: []streamstypes.AttributeValue{
+			&streamstypes.AttributeValueMemberM{Value: map[string]streamstypes.AttributeValue{
+				"name": &streamstypes.AttributeValueMemberS{Value: "apple"},
+			}},
+		}},
+		"attrs": &streamstypes.AttributeValueMemberM{Value: map[string]streamstypes.AttributeValue{
+			"ns":   &streamstypes.AttributeValueMemberNS{Value: []string{"1", "2"}},
+			"null": &streamstypes.AttributeValueMemberNULL{Value: true},
+			"l": &streamstypes.AttributeValueMemberL{Value: []streamstypes.AttributeValue{
+				&streamstypes.AttributeValueMemberBOOL{Value: true},
+			}},
+		}},
+		"score": &streamstypes.AttributeValueMemberN{Value: "12345678901234567890.5"},
+		"raw": &streamstypes.AttributeValueMemberM{Value: map[string]streamstypes.AttributeValue{
+			"b": &streamstypes.AttributeValueMemberB{Value: []byte("x")},
+		}},
+	}
+}
+
+func TestUnmarshalImage_Struct(t *testing.T) {
+	var got User
+	if err := UnmarshalImage(image(), &got); err != nil {
+		t.Fatalf("UnmarshalImage() error = %v", err)
+	}
+	want := User{
+		ID:    "u1",
+		Age:   30,
+		Tags:  sqldav.Set[string]{"a", "b"},
+		Items: sqldav.TypedList[Item]{{Name: "apple"}},
+		Attrs: sqldav.Map{"ns": sqldav.Set[int]{1, 2}, "null": nil, "l": sqldav.List{true}},
+		Score: "12345678901234567890.5",
+		Raw:   sqldav.TypedMap[[]byte]{"b": []byte("x")},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("UnmarshalImage() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestUnmarshalImage_Map checks that the image is decoded with the same rules as the map from the driver.
+func TestUnmarshalImage_Map(t *testing.T) {
+	var got sqldav.Map
+	if err := UnmarshalImage(image(), &got); err != nil {
+		t.Fatalf("UnmarshalImage() error = %v", err)
+	}
+	var want sqldav.Map
+	err := want.Scan(map[string]interface{}{
+		"id":    "u1",
+		"age":   float64(30),
+		"tags":  []string{"a", "b"},
+		"items": []interface{}{map[string]interface{}{"name": "apple"}},
+		"attrs": map[string]interface{}{"ns": []float64{1, 2}, "null": nil, "l": []interface{}{true}},
+		"score": 12345678901234567890.5,
+		"raw":   map[string]interface{}{"b": []byte("x")},
+	})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("UnmarshalImage() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshal_TypedList(t *testing.T) {
+	var got sqldav.TypedList[Item]
+	err := Unmarshal(&streamstypes.AttributeValueMemberL{Value: []streamstypes.AttributeValue{
+		&streamstypes.AttributeValueMemberM{Value: map[string]streamstypes.AttributeValue{
+			"name": &streamstypes.AttributeValueMemberS{Value: "apple"},
+		}},
+	}}, &got)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if diff := cmp.Diff(sqldav.TypedList[Item]{{Name: "apple"}}, got); diff != "" {
+		t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshalRecord(t *testing.T) {
+	keys := map[string]streamstypes.AttributeValue{"id": &streamstypes.AttributeValueMemberS{Value: "u1"}}
+	modify := streamstypes.Record{
+		EventName: streamstypes.OperationTypeModify,
+		Dynamodb:  &streamstypes.StreamRecord{Keys: keys, NewImage: image(), OldImage: keys},
+	}
+	var newImage, oldImage, key User
+	if err := UnmarshalNewImage(modify, &newImage); err != nil {
+		t.Fatalf("UnmarshalNewImage() error = %v", err)
+	}
+	if newImage.Age != 30 {
+		t.Errorf("UnmarshalNewImage() = %v, want the NewImage", newImage)
+	}
+	if err := UnmarshalOldImage(modify, &oldImage); err != nil {
+		t.Fatalf("UnmarshalOldImage() error = %v", err)
+	}
+	if err := UnmarshalKeys(modify, &key); err != nil {
+		t.Fatalf("UnmarshalKeys() error = %v", err)
+	}
+	if diff := cmp.Diff(User{ID: "u1"}, key); diff != "" {
+		t.Errorf("UnmarshalKeys() mismatch (-want +got):\n%s", diff)
+	}
+
+	remove := streamstypes.Record{
+		EventName: streamstypes.OperationTypeRemove,
+		Dynamodb:  &streamstypes.StreamRecord{Keys: keys, OldImage: image()},
+	}
+	if err := UnmarshalNewImage(remove, &newImage); !errors.Is(err, ErrMissingImage) {
+		t.Errorf("UnmarshalNewImage() error = %v, want %v", err, ErrMissingImage)
+	}
+	if err := UnmarshalOldImage(streamstypes.Record{}, &oldImage); !errors.Is(err, ErrMissingImage) {
+		t.Errorf("UnmarshalOldImage() error = %v, want %v", err, ErrMissingImage)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	d := NewDecoder(sqldav.WithDisallowUnknownAttributes())
+	var got Item
+	err := d.DecodeImage(map[string]streamstypes.AttributeValue{
+		"name":  &streamstypes.AttributeValueMemberS{Value: "apple"},
+		"price": &streamstypes.AttributeValueMemberN{Value: "1"},
+	}, &got)
+	var de *sqldav.DecodeError
+	if !errors.Is(err, sqldav.ErrUnknownAttribute) || !errors.As(err, &de) || de.Path != "price" {
+		t.Errorf("DecodeImage() error = %v, want the unknown attribute price", err)
+	}
+}
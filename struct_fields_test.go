@@ -0,0 +1,124 @@
+package sqldav
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+type Audit struct {
+	CreatedBy string
+	UpdatedBy string
+}
+
+type Timestamps struct {
+	CreatedAt int
+	UpdatedAt int
+}
+
+type versioned struct {
+	Version int
+}
+
+type shadowing struct {
+	Name string
+}
+
+type conflictingA struct {
+	Conflict string
+	Tagged   string `dynamodbav:"tagged"`
+}
+
+type conflictingB struct {
+	Conflict string
+	Tagged   string
+}
+
+type Owner struct {
+	Name string
+}
+
+type embeddingTarget struct {
+	Audit
+	*Timestamps
+	versioned
+	shadowing
+	conflictingA
+	conflictingB
+	Owner   `dynamodbav:"owner"`
+	Profile Address `dynamodbav:",inline"`
+	Name    string
+}
+
+func TestMarshal_EmbeddedStruct(t *testing.T) {
+	v := embeddingTarget{
+		Audit:        Audit{CreatedBy: "alice", UpdatedBy: "bob"},
+		Timestamps:   &Timestamps{CreatedAt: 1, UpdatedAt: 2},
+		versioned:    versioned{Version: 3},
+		shadowing:    shadowing{Name: "shadowed"},
+		conflictingA: conflictingA{Conflict: "a", Tagged: "a"},
+		conflictingB: conflictingB{Conflict: "b", Tagged: "b"},
+		Owner:        Owner{Name: "carol"},
+		Profile:      Address{Zip: "100-0001", Floor: 4},
+		Name:         "dave",
+	}
+	want := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"created_by": &types.AttributeValueMemberS{Value: "alice"},
+		"updated_by": &types.AttributeValueMemberS{Value: "bob"},
+		"created_at": &types.AttributeValueMemberN{Value: "1"},
+		"updated_at": &types.AttributeValueMemberN{Value: "2"},
+		"version":    &types.AttributeValueMemberN{Value: "3"},
+		"tagged":     &types.AttributeValueMemberS{Value: "a"},
+		"owner": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"name": &types.AttributeValueMemberS{Value: "carol"},
+		}},
+		"zip":    &types.AttributeValueMemberS{Value: "100-0001"},
+		"street": &types.AttributeValueMemberNULL{Value: true},
+		"floor":  &types.AttributeValueMemberN{Value: "4"},
+		"name":   &types.AttributeValueMemberS{Value: "dave"},
+	}}
+	opts := []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberN{}),
+		cmp.AllowUnexported(types.AttributeValueMemberM{}),
+		cmp.AllowUnexported(types.AttributeValueMemberNULL{}),
+	}
+	got, err := Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Errorf("Marshal() mismatch (-want +got):\n%s", diff)
+	}
+
+	var decoded embeddingTarget
+	if err := Unmarshal(got, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	v.shadowing = shadowing{}
+	v.conflictingA.Conflict = ""
+	v.conflictingB = conflictingB{}
+	if diff := cmp.Diff(v, decoded, cmp.AllowUnexported(embeddingTarget{})); diff != "" {
+		t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMarshal_EmbeddedStruct_NilPointer(t *testing.T) {
+	got, err := Marshal(struct {
+		*Timestamps
+		Name string
+	}{Name: "foo"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"name": &types.AttributeValueMemberS{Value: "foo"},
+	}}
+	opts := []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberM{}),
+	}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Errorf("Marshal() mismatch (-want +got):\n%s", diff)
+	}
+}
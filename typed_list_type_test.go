@@ -1,6 +1,7 @@
 package sqldav
 
 import (
+	"database/sql/driver"
 	"errors"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
@@ -276,7 +277,7 @@ func TestTypedList_Value(t *testing.T) {
 								"f": &types.AttributeValueMemberN{Value: "1"},
 								"g": &types.AttributeValueMemberBOOL{Value: true},
 								"h": &types.AttributeValueMemberN{Value: "1.1"},
-								"i": &types.AttributeValueMemberSS{Value: []string{"foo", "bar", "baz"}},
+								"i": &types.AttributeValueMemberSS{Value: []string{"bar", "baz", "foo"}},
 								"j": &types.AttributeValueMemberB{Value: []byte("foo")},
 								"k": &types.AttributeValueMemberB{Value: []byte("bar")},
 							}}}}}}}
@@ -303,3 +304,146 @@ func TestTypedList_Value(t *testing.T) {
 		})
 	}
 }
+
+func TestTypedList_Scan_ElementTypes(t *testing.T) {
+	type testCase struct {
+		sut           interface{ Scan(interface{}) error }
+		args          interface{}
+		expectedState interface{}
+		want          error
+	}
+	tests := map[string]testCase{
+		"happy-path/string": {
+			sut:           &TypedList[string]{},
+			args:          []interface{}{"foo", "bar"},
+			expectedState: &TypedList[string]{"foo", "bar"},
+		},
+		"happy-path/int": {
+			sut:           &TypedList[int]{},
+			args:          []interface{}{float64(1), float64(2)},
+			expectedState: &TypedList[int]{1, 2},
+		},
+		"happy-path/set": {
+			sut:           &TypedList[Set[string]]{},
+			args:          []interface{}{[]string{"foo"}, []string{"bar", "baz"}},
+			expectedState: &TypedList[Set[string]]{{"foo"}, {"bar", "baz"}},
+		},
+		"happy-path/nested-list": {
+			sut: &TypedList[TypedList[Address]]{},
+			args: []interface{}{
+				[]interface{}{map[string]interface{}{"zip": "100-0001"}},
+				[]interface{}{},
+			},
+			expectedState: &TypedList[TypedList[Address]]{{{Zip: "100-0001"}}, nil},
+		},
+		"happy-path/pointer-with-null": {
+			sut:           &TypedList[*Address]{},
+			args:          []interface{}{map[string]interface{}{"zip": "100-0001"}, nil},
+			expectedState: &TypedList[*Address]{{Zip: "100-0001"}, nil},
+		},
+		"unhappy-path/incompatible-scalar": {
+			sut:           &TypedList[string]{},
+			args:          []interface{}{float64(1)},
+			expectedState: &TypedList[string]{},
+			want:          ErrFailedToCast,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.sut.Scan(tt.args)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Scan() error = %v, want %v", err, tt.want)
+				return
+			}
+			if diff := cmp.Diff(tt.expectedState, tt.sut); diff != "" {
+				t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+				return
+			}
+		})
+	}
+}
+
+func TestTypedList_Value_ElementTypes(t *testing.T) {
+	type want struct {
+		value *types.AttributeValueMemberL
+		error error
+	}
+	type test struct {
+		sut  interface{ Value() (driver.Value, error) }
+		want want
+	}
+	tests := map[string]test{
+		"happy-path/string": {
+			sut: TypedList[string]{"foo", "bar"},
+			want: want{
+				value: &types.AttributeValueMemberL{Value: []types.AttributeValue{
+					&types.AttributeValueMemberS{Value: "foo"},
+					&types.AttributeValueMemberS{Value: "bar"},
+				}},
+			},
+		},
+		"happy-path/int": {
+			sut: TypedList[int]{1, 2},
+			want: want{
+				value: &types.AttributeValueMemberL{Value: []types.AttributeValue{
+					&types.AttributeValueMemberN{Value: "1"},
+					&types.AttributeValueMemberN{Value: "2"},
+				}},
+			},
+		},
+		"happy-path/set": {
+			sut: TypedList[Set[string]]{{"foo"}},
+			want: want{
+				value: &types.AttributeValueMemberL{Value: []types.AttributeValue{
+					&types.AttributeValueMemberSS{Value: []string{"foo"}},
+				}},
+			},
+		},
+		"happy-path/nested-list": {
+			sut: TypedList[TypedList[Address]]{{{Zip: "100-0001"}}},
+			want: want{
+				value: &types.AttributeValueMemberL{Value: []types.AttributeValue{
+					&types.AttributeValueMemberL{Value: []types.AttributeValue{
+						&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+							"zip":    &types.AttributeValueMemberS{Value: "100-0001"},
+							"street": &types.AttributeValueMemberNULL{Value: true},
+							"floor":  &types.AttributeValueMemberN{Value: "0"},
+						}},
+					}},
+				}},
+			},
+		},
+		"happy-path/pointer-with-null": {
+			sut: TypedList[*Address]{{Zip: "100-0001"}, nil},
+			want: want{
+				value: &types.AttributeValueMemberL{Value: []types.AttributeValue{
+					&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+						"zip":    &types.AttributeValueMemberS{Value: "100-0001"},
+						"street": &types.AttributeValueMemberNULL{Value: true},
+						"floor":  &types.AttributeValueMemberN{Value: "0"},
+					}},
+					&types.AttributeValueMemberNULL{Value: true},
+				}},
+			},
+		},
+	}
+	opts := []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberSS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberN{}),
+		cmp.AllowUnexported(types.AttributeValueMemberL{}),
+		cmp.AllowUnexported(types.AttributeValueMemberM{}),
+		cmp.AllowUnexported(types.AttributeValueMemberNULL{}),
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.sut.Value()
+			if !errors.Is(err, tt.want.error) {
+				t.Errorf("Value() error = %v, want %v", err, tt.want.error)
+			}
+			if diff := cmp.Diff(tt.want.value, got, opts...); diff != "" {
+				t.Errorf("Value() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package sqldav
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+type LocaleSetting struct {
+	Title    string
+	Priority int
+	Enabled  *bool
+	Tags     Set[string]
+}
+
+func TestTypedMap_Scan(t *testing.T) {
+	type testCase struct {
+		sut           TypedMap[LocaleSetting]
+		args          interface{}
+		expectedState TypedMap[LocaleSetting]
+		want          error
+	}
+	tests := map[string]testCase{
+		"happy-path/empty-map": {
+			sut:           TypedMap[LocaleSetting]{},
+			args:          map[string]interface{}{},
+			expectedState: TypedMap[LocaleSetting]{},
+		},
+		"happy-path/multiple-values": {
+			sut: TypedMap[LocaleSetting]{},
+			args: map[string]interface{}{
+				"ja-JP": map[string]interface{}{
+					"title":    "こんにちは",
+					"priority": float64(1),
+					"enabled":  true,
+					"tags":     []string{"a", "b"},
+				},
+				"en-US": map[string]interface{}{
+					"title":    "hello",
+					"priority": float64(2),
+					"enabled":  nil,
+				},
+			},
+			expectedState: TypedMap[LocaleSetting]{
+				"ja-JP": {
+					Title:    "こんにちは",
+					Priority: 1,
+					Enabled:  aws.Bool(true),
+					Tags:     Set[string]{"a", "b"},
+				},
+				"en-US": {
+					Title:    "hello",
+					Priority: 2,
+				},
+			},
+		},
+		"unhappy-path/non-map-value": {
+			sut:           TypedMap[LocaleSetting]{},
+			args:          "non-map",
+			expectedState: TypedMap[LocaleSetting]{},
+			want:          ErrFailedToCast,
+		},
+		"unhappy-path/invalid-map-entry": {
+			sut:           TypedMap[LocaleSetting]{},
+			args:          map[string]interface{}{"ja-JP": "non-map"},
+			expectedState: TypedMap[LocaleSetting]{},
+			want:          ErrFailedToCast,
+		},
+		"unhappy-path/incompatible-attribute": {
+			sut:           TypedMap[LocaleSetting]{},
+			args:          map[string]interface{}{"ja-JP": map[string]interface{}{"title": float64(1)}},
+			expectedState: TypedMap[LocaleSetting]{},
+			want:          ErrNestedStructHasIncompatibleAttributes,
+		},
+		"unhappy-path/sut-is-not-empty": {
+			sut:           TypedMap[LocaleSetting]{"ja-JP": {Title: "foo"}},
+			args:          map[string]interface{}{},
+			expectedState: TypedMap[LocaleSetting]{"ja-JP": {Title: "foo"}},
+			want:          ErrCollectionAlreadyContainsItem,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.sut.Scan(tt.args)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Scan() error = %v, want %v", err, tt.want)
+				return
+			}
+			if diff := cmp.Diff(tt.expectedState, tt.sut); diff != "" {
+				t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+				return
+			}
+		})
+	}
+}
+
+func TestTypedMap_Value(t *testing.T) {
+	type want struct {
+		value *types.AttributeValueMemberM
+		error error
+	}
+	type test struct {
+		sut  TypedMap[LocaleSetting]
+		want want
+	}
+	tests := map[string]test{
+		"happy-path": {
+			sut: TypedMap[LocaleSetting]{
+				"ja-JP": {
+					Title:    "こんにちは",
+					Priority: 1,
+					Enabled:  aws.Bool(true),
+					Tags:     Set[string]{"b", "a"},
+				},
+			},
+			want: want{
+				value: &types.AttributeValueMemberM{
+					Value: map[string]types.AttributeValue{
+						"ja-JP": &types.AttributeValueMemberM{
+							Value: map[string]types.AttributeValue{
+								"title":    &types.AttributeValueMemberS{Value: "こんにちは"},
+								"priority": &types.AttributeValueMemberN{Value: "1"},
+								"enabled":  &types.AttributeValueMemberBOOL{Value: true},
+								"tags":     &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+							}}}},
+			},
+		},
+		"happy-path/empty-map": {
+			sut: TypedMap[LocaleSetting]{},
+			want: want{
+				value: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{}},
+			},
+		},
+		"unhappy-path/empty-set": {
+			sut: TypedMap[LocaleSetting]{"ja-JP": {Tags: Set[string]{}}},
+			want: want{
+				error: ErrSetIsEmpty,
+			},
+		},
+	}
+	opts := []cmp.Option{
+		cmp.AllowUnexported(types.AttributeValueMemberS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberSS{}),
+		cmp.AllowUnexported(types.AttributeValueMemberN{}),
+		cmp.AllowUnexported(types.AttributeValueMemberM{}),
+		cmp.AllowUnexported(types.AttributeValueMemberBOOL{}),
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.sut.Value()
+			if !errors.Is(err, tt.want.error) {
+				t.Errorf("Value() error = %v, want %v", err, tt.want.error)
+			}
+			if tt.want.value == nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want.value, got, opts...); diff != "" {
+				t.Errorf("Value() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTypedMap_GormDataType(t *testing.T) {
+	if got := (&TypedMap[LocaleSetting]{}).GormDataType(); got != "M" {
+		t.Errorf("GormDataType() = %v, want %v", got, "M")
+	}
+}
+
+func TestTypedMap_Scan_ElementTypes(t *testing.T) {
+	type testCase struct {
+		sut           interface{ Scan(interface{}) error }
+		args          interface{}
+		expectedState interface{}
+		want          error
+	}
+	tests := map[string]testCase{
+		"happy-path/int": {
+			sut:           &TypedMap[int]{},
+			args:          map[string]interface{}{"a": float64(1)},
+			expectedState: &TypedMap[int]{"a": 1},
+		},
+		"happy-path/pointer-with-null": {
+			sut:           &TypedMap[*LocaleSetting]{},
+			args:          map[string]interface{}{"ja-JP": map[string]interface{}{"title": "foo"}, "en-US": nil},
+			expectedState: &TypedMap[*LocaleSetting]{"ja-JP": {Title: "foo"}, "en-US": nil},
+		},
+		"happy-path/attribute-value": {
+			sut: &TypedMap[Set[Number]]{},
+			args: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"a": &types.AttributeValueMemberNS{Value: []string{"12345678901234567890.5"}},
+				"b": &types.AttributeValueMemberNULL{Value: true},
+			}},
+			expectedState: &TypedMap[Set[Number]]{"a": {"12345678901234567890.5"}, "b": nil},
+		},
+		"happy-path/nested-attribute-value": {
+			sut: &TypedMap[List]{},
+			args: map[string]interface{}{"a": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+				&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"b": &types.AttributeValueMemberBOOL{Value: true}}},
+			}}},
+			expectedState: &TypedMap[List]{"a": {Map{"b": true}}},
+		},
+		"unhappy-path/incompatible-scalar": {
+			sut:           &TypedMap[string]{},
+			args:          map[string]interface{}{"a": true},
+			expectedState: &TypedMap[string]{},
+			want:          ErrFailedToCast,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.sut.Scan(tt.args)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Scan() error = %v, want %v", err, tt.want)
+				return
+			}
+			if diff := cmp.Diff(tt.expectedState, tt.sut); diff != "" {
+				t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+				return
+			}
+		})
+	}
+}
@@ -1,14 +1,19 @@
 package sqldav
 
 import (
+	"bytes"
+	"cmp"
 	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"math"
 	"reflect"
 	"slices"
+	"strconv"
+	"strings"
 )
 
 var (
@@ -20,15 +25,30 @@ var (
 	ErrFailedToCast                      = errors.New("failed to cast")
 )
 
+// DynamoDB set constraint errors.
+//
+// See: https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/HowItWorks.NamingRulesDataTypes.html#HowItWorks.DataTypes.SetTypes
+var (
+	ErrSetIsEmpty               = errors.New("set is empty")
+	ErrSetContainsEmptyString   = errors.New("set contains empty string")
+	ErrSetContainsEmptyBinary   = errors.New("set contains empty binary")
+	ErrSetContainsInvalidNumber = errors.New("set contains number that is not supported by DynamoDB")
+)
+
 // SetSupportable are the types that support the Set
 type SetSupportable interface {
-	string | []byte | int | float64
+	~string | ~[]byte |
+		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
 }
 
 // compatibility check
 var (
-	_ driver.Valuer = (*Set[string])(nil)
-	_ sql.Scanner   = (*Set[string])(nil)
+	_ driver.Valuer              = (*Set[string])(nil)
+	_ sql.Scanner                = (*Set[string])(nil)
+	_ attributevalue.Marshaler   = (*Set[string])(nil)
+	_ attributevalue.Unmarshaler = (*Set[string])(nil)
 )
 
 // Set is a DynamoDB set type.
@@ -40,148 +60,458 @@ type Set[T SetSupportable] []T
 //
 // [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
 func (s *Set[T]) Scan(value interface{}) error {
+	return scanWithDefaultDecoder(s, value)
+}
+
+// scan assigns the value from the driver, or the types.AttributeValue, to the set.
+func (s *Set[T]) scan(value interface{}) error {
 	if len(*s) != 0 {
 		return ErrCollectionAlreadyContainsItem
 	}
-	if value == nil {
+	if isNull(value) {
 		*s = nil
 		return nil
 	}
-	switch (interface{})(s).(type) {
-	case *Set[int]:
-		return scanAsIntSet((interface{})(s).(*Set[int]), value)
-	case *Set[float64]:
-		return scanAsFloat64Set((interface{})(s).(*Set[float64]), value)
-	case *Set[string]:
-		return scanAsStringSet((interface{})(s).(*Set[string]), value)
-	case *Set[[]byte]:
-		return scanAsBinarySet((interface{})(s).(*Set[[]byte]), value)
+	if av, ok := value.(types.AttributeValue); ok {
+		v, err := attributeValueToInterface(av, true)
+		if err != nil {
+			return err
+		}
+		value = v
+	}
+	if sa, ok := value.(setAttribute); ok {
+		value = sa.setElements()
+	}
+	if sv, ok := value.([]T); ok {
+		*s = append(*s, sv...)
+		return nil
+	}
+	if lv, ok := value.([]interface{}); ok {
+		ev, err := setElementsOf[T](lv)
+		if err != nil {
+			*s = nil
+			return err
+		}
+		value = ev
+	}
+	switch setElementKindOf[T]() {
+	case setElementKindString:
+		return scanAsStringSet(s, value)
+	case setElementKindBinary:
+		return scanAsBinarySet(s, value)
+	case setElementKindInt, setElementKindUint:
+		return scanAsIntSet(s, value)
+	case setElementKindFloat:
+		return scanAsFloatSet(s, value)
+	case setElementKindNumber:
+		return scanAsNumberSet((interface{})(s).(*Set[Number]), value)
 	}
 	return nil
 }
 
+// Value implements the [driver.Valuer] interface.
+//
+// The elements are de-duplicated and sorted, so the same set always results in the same attribute value.
+// Returns ErrSetIsEmpty if the set is empty. Use NullIfEmpty to convert the empty set to NULL instead.
+//
+// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
 func (s Set[T]) Value() (v driver.Value, err error) {
-	switch s := (interface{})(s).(type) {
-	case Set[int]:
-		v, err = numericSetToAttributeValue(s)
-	case Set[float64]:
-		v, err = numericSetToAttributeValue(s)
-	case Set[string]:
+	switch setElementKindOf[T]() {
+	case setElementKindString:
 		v, err = stringSetToAttributeValue(s)
-	case Set[[]byte]:
+	case setElementKindBinary:
 		v, err = binarySetToAttributeValue(s)
+	default:
+		v, err = numericSetToAttributeValue(s)
 	}
 	return
 }
 
+// NullIfEmpty returns a [driver.Valuer] that converts the empty set to NULL instead of returning ErrSetIsEmpty.
+//
+// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
+func (s Set[T]) NullIfEmpty() driver.Valuer {
+	return nullIfEmptySet[T](s)
+}
+
+// nullIfEmptySet is a Set that converts to NULL if it is empty.
+type nullIfEmptySet[T SetSupportable] Set[T]
+
+// Value implements the [driver.Valuer] interface.
+//
+// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
+func (s nullIfEmptySet[T]) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return &types.AttributeValueMemberNULL{Value: true}, nil
+	}
+	return Set[T](s).Value()
+}
+
 // GormDataType returns the data type for Gorm.
 func (s *Set[T]) GormDataType() string {
-	var t T
-	switch (interface{})(t).(type) {
-	case string:
+	switch setElementKindOf[T]() {
+	case setElementKindString:
 		return "SS"
-	case int:
-		return "NS"
-	case float64:
-		return "NS"
-	case []byte:
+	case setElementKindBinary:
 		return "BS"
+	case setElementKindInt, setElementKindUint, setElementKindFloat, setElementKindNumber:
+		return "NS"
 	}
 	return "SS"
 }
 
-func numericSetToAttributeValue[T Set[int] | Set[float64]](s T) (*types.AttributeValueMemberNS, error) {
+// encodeWith converts the set to a types.AttributeValue with the Encoder.
+func (s Set[T]) encodeWith(e *Encoder) (types.AttributeValue, error) {
+	if len(s) == 0 && e.emptySetAsNull {
+		return newNullAttributeValue(), nil
+	}
+	s, err := s.canonicalize()
+	if err != nil {
+		return nil, err
+	}
+	switch setElementKindOf[T]() {
+	case setElementKindString:
+		ss := make([]string, 0, len(s))
+		for _, v := range s {
+			ss = append(ss, reflect.ValueOf(v).String())
+		}
+		return &types.AttributeValueMemberSS{Value: ss}, nil
+	case setElementKindBinary:
+		bs := make([][]byte, 0, len(s))
+		for _, v := range s {
+			bs = append(bs, reflect.ValueOf(v).Bytes())
+		}
+		return &types.AttributeValueMemberBS{Value: bs}, nil
+	}
+	ns := make([]string, 0, len(s))
+	for _, v := range s {
+		ns = append(ns, formatSetNumber(v))
+	}
+	return &types.AttributeValueMemberNS{Value: ns}, nil
+}
+
+// decodeWith assigns the set to the set. The elements are not decoded with the Decoder.
+func (s *Set[T]) decodeWith(_ *Decoder, value interface{}) error {
+	return s.scan(value)
+}
+
+// setAttribute is implemented by Set to tell its elements to the Decoder, whatever the element type is.
+type setAttribute interface {
+	setElements() interface{}
+}
+
+// setElements returns the elements of the set in the shape of the values from the driver:
+// []string for the string set, [][]byte for the binary set and []Number for the numeric set.
+func (s Set[T]) setElements() interface{} {
+	switch setElementKindOf[T]() {
+	case setElementKindString:
+		ss := make([]string, 0, len(s))
+		for _, v := range s {
+			ss = append(ss, reflect.ValueOf(v).String())
+		}
+		return ss
+	case setElementKindBinary:
+		bs := make([][]byte, 0, len(s))
+		for _, v := range s {
+			bs = append(bs, reflect.ValueOf(v).Bytes())
+		}
+		return bs
+	}
+	ns := make([]Number, 0, len(s))
+	for _, v := range s {
+		ns = append(ns, Number(formatSetNumber(v)))
+	}
+	return ns
+}
+
+// MarshalDynamoDBAttributeValue implements the [attributevalue.Marshaler] interface.
+//
+// Unlike Value, the empty set is converted to NULL, as the AWS SDK converts nil slices.
+//
+// [attributevalue.Marshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Marshaler
+func (s Set[T]) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	if len(s) == 0 {
+		return newNullAttributeValue(), nil
+	}
+	return s.encodeWith(defaultEncoder)
+}
+
+// UnmarshalDynamoDBAttributeValue implements the [attributevalue.Unmarshaler] interface.
+//
+// The elements of the set are replaced. NULL is converted to the nil set.
+//
+// [attributevalue.Unmarshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Unmarshaler
+func (s *Set[T]) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	*s = nil
+	return s.decodeWith(defaultDecoder.Load(), av)
+}
+
+func numericSetToAttributeValue[T SetSupportable](s Set[T]) (*types.AttributeValueMemberNS, error) {
 	return ToDocumentAttributeValue[*types.AttributeValueMemberNS](s)
 }
 
-func stringSetToAttributeValue(s Set[string]) (*types.AttributeValueMemberSS, error) {
+func stringSetToAttributeValue[T SetSupportable](s Set[T]) (*types.AttributeValueMemberSS, error) {
 	return ToDocumentAttributeValue[*types.AttributeValueMemberSS](s)
 }
 
-func binarySetToAttributeValue(s Set[[]byte]) (*types.AttributeValueMemberBS, error) {
+func binarySetToAttributeValue[T SetSupportable](s Set[T]) (*types.AttributeValueMemberBS, error) {
 	return ToDocumentAttributeValue[*types.AttributeValueMemberBS](s)
 }
 
-// scanAsIntSet scans the value as Set[int]
-func scanAsIntSet(s *Set[int], value interface{}) error {
-	sv, ok := value.([]float64)
-	if !ok {
-		*s = nil
-		return ErrValueIsIncompatibleOfIntSlice
+var (
+	// ErrValueOverflowsSetElement occurs when the value overflows the element type of the Set.
+	ErrValueOverflowsSetElement = errors.New("value overflows set element")
+	// ErrValueLosesPrecisionOfSetElement occurs when the value cannot be represented by the float element type of the Set,
+	// such as 1e-50 and 0.123456789 for float32.
+	ErrValueLosesPrecisionOfSetElement = errors.New("value loses precision of set element")
+)
+
+// setElementsOf converts the elements of []interface{}, which some drivers return for sets,
+// to []string, [][]byte or []Number according to the element kind of the Set.
+func setElementsOf[T SetSupportable](lv []interface{}) (interface{}, error) {
+	switch setElementKindOf[T]() {
+	case setElementKindString:
+		ss := make([]string, 0, len(lv))
+		for _, v := range lv {
+			str, ok := v.(string)
+			if !ok {
+				return nil, errors.Join(ErrValueIsIncompatibleOfStringSlice, fmt.Errorf("incompatible string and %T", v))
+			}
+			ss = append(ss, str)
+		}
+		return ss, nil
+	case setElementKindBinary:
+		bs := make([][]byte, 0, len(lv))
+		for _, v := range lv {
+			b, ok := v.([]byte)
+			if !ok {
+				return nil, errors.Join(ErrValueIsIncompatibleOfBinarySlice, fmt.Errorf("incompatible []byte and %T", v))
+			}
+			bs = append(bs, b)
+		}
+		return bs, nil
 	}
-	for _, v := range sv {
-		if math.Floor(v) != v {
-			*s = nil
-			return ErrValueIsIncompatibleOfIntSlice
+	ns := make([]Number, 0, len(lv))
+	for _, v := range lv {
+		n, err := toNumber(v)
+		if err != nil {
+			return nil, err
+		}
+		ns = append(ns, n)
+	}
+	return ns, nil
+}
+
+// scanAsIntSet scans the value as the set of integer kind
+func scanAsIntSet[T SetSupportable](s *Set[T], value interface{}) error {
+	switch value := value.(type) {
+	case []float64:
+		for _, v := range value {
+			e, err := setElementFromFloat64[T](v)
+			if err != nil {
+				*s = nil
+				return errors.Join(ErrValueIsIncompatibleOfIntSlice, err)
+			}
+			*s = append(*s, e)
 		}
-		*s = append(*s, int(v))
+	case []string:
+		for _, v := range value {
+			e, err := setElementFromNumber[T](Number(v))
+			if err != nil {
+				*s = nil
+				return errors.Join(ErrValueIsIncompatibleOfIntSlice, err)
+			}
+			*s = append(*s, e)
+		}
+	case []Number:
+		for _, v := range value {
+			e, err := setElementFromNumber[T](v)
+			if err != nil {
+				*s = nil
+				return errors.Join(ErrValueIsIncompatibleOfIntSlice, err)
+			}
+			*s = append(*s, e)
+		}
+	default:
+		*s = nil
+		return ErrValueIsIncompatibleOfIntSlice
 	}
 	return nil
 }
 
-// scanAsFloat64Set scans the value as Set[float64]
-func scanAsFloat64Set(s *Set[float64], value interface{}) error {
-	sv, ok := value.([]float64)
-	if !ok {
+// scanAsFloatSet scans the value as the set of float kind
+func scanAsFloatSet[T SetSupportable](s *Set[T], value interface{}) error {
+	switch value := value.(type) {
+	case []float64:
+		for _, v := range value {
+			e, err := setElementFromFloat64[T](v)
+			if err != nil {
+				*s = nil
+				return errors.Join(ErrValueIsIncompatibleOfFloat64Slice, err)
+			}
+			*s = append(*s, e)
+		}
+	case []Number:
+		for _, v := range value {
+			e, err := setElementFromNumber[T](v)
+			if err != nil {
+				*s = nil
+				return errors.Join(ErrValueIsIncompatibleOfFloat64Slice, err)
+			}
+			*s = append(*s, e)
+		}
+	default:
 		*s = nil
 		return ErrValueIsIncompatibleOfFloat64Slice
 	}
-	for _, v := range sv {
-		*s = append(*s, v)
-	}
 	return nil
 }
 
-// scanAsStringSet scans the value as Set[string]
-func scanAsStringSet(s *Set[string], value interface{}) error {
+// scanAsStringSet scans the value as the set of string kind
+func scanAsStringSet[T SetSupportable](s *Set[T], value interface{}) error {
 	sv, ok := value.([]string)
 	if !ok {
 		*s = nil
 		return ErrValueIsIncompatibleOfStringSlice
 	}
+	rt := reflect.TypeOf((*T)(nil)).Elem()
 	for _, v := range sv {
-		*s = append(*s, v)
+		*s = append(*s, reflect.ValueOf(v).Convert(rt).Interface().(T))
 	}
 	return nil
 }
 
-// scanAsBinarySet scans the value as Set[[]byte]
-func scanAsBinarySet(s *Set[[]byte], value interface{}) error {
+// scanAsBinarySet scans the value as the set of []byte kind
+func scanAsBinarySet[T SetSupportable](s *Set[T], value interface{}) error {
 	sv, ok := value.([][]byte)
 	if !ok {
 		*s = nil
 		return ErrValueIsIncompatibleOfBinarySlice
 	}
+	rt := reflect.TypeOf((*T)(nil)).Elem()
 	for _, v := range sv {
-		*s = append(*s, v)
+		*s = append(*s, reflect.ValueOf(v).Convert(rt).Interface().(T))
 	}
 	return nil
 }
 
-func isCompatibleWithSet[T SetSupportable](value interface{}) (compatible bool) {
+// setElementFromFloat64 converts the float64 to the element of the numeric set.
+//
+// Returns an error if the value has a fractional part, overflows the element type
+// or loses precision in the element type.
+func setElementFromFloat64[T SetSupportable](v float64) (T, error) {
 	var t T
-	switch (interface{})(t).(type) {
-	case string:
-		compatible = isStringSetCompatible(value)
-	case int:
-		compatible = isIntSetCompatible(value)
-	case float64:
-		compatible = isFloat64SetCompatible(value)
-	case []byte:
-		compatible = isBinarySetCompatible(value)
+	rv := reflect.ValueOf(&t).Elem()
+	switch setElementKindOf[T]() {
+	case setElementKindInt:
+		if math.Trunc(v) != v {
+			return t, fmt.Errorf("%v is not integer", v)
+		}
+		if v < math.MinInt64 || v >= math.MaxInt64 || rv.OverflowInt(int64(v)) {
+			return t, errors.Join(ErrValueOverflowsSetElement, fmt.Errorf("%v overflows %T", v, t))
+		}
+		rv.SetInt(int64(v))
+	case setElementKindUint:
+		if math.Trunc(v) != v {
+			return t, fmt.Errorf("%v is not integer", v)
+		}
+		if v < 0 || v >= math.MaxUint64 || rv.OverflowUint(uint64(v)) {
+			return t, errors.Join(ErrValueOverflowsSetElement, fmt.Errorf("%v overflows %T", v, t))
+		}
+		rv.SetUint(uint64(v))
+	case setElementKindFloat:
+		if rv.OverflowFloat(v) {
+			return t, errors.Join(ErrValueOverflowsSetElement, fmt.Errorf("%v overflows %T", v, t))
+		}
+		if bits := rv.Type().Bits(); bits < 64 && !isRepresentableFloat(v, bits) {
+			return t, errors.Join(ErrValueLosesPrecisionOfSetElement, fmt.Errorf("%v loses precision in %T", v, t))
+		}
+		rv.SetFloat(v)
+	default:
+		return t, fmt.Errorf("incompatible %T and %T", t, v)
 	}
-	return
+	return t, nil
+}
+
+// setElementFromNumber converts the Number to the element of the numeric set without loss of precision.
+//
+// Returns an error if the value has a fractional part or overflows the element type.
+func setElementFromNumber[T SetSupportable](n Number) (T, error) {
+	var t T
+	rv := reflect.ValueOf(&t).Elem()
+	switch setElementKindOf[T]() {
+	case setElementKindInt:
+		i, err := n.Int64()
+		if err != nil {
+			return t, err
+		}
+		if rv.OverflowInt(i) {
+			return t, errors.Join(ErrValueOverflowsSetElement, fmt.Errorf("%v overflows %T", n, t))
+		}
+		rv.SetInt(i)
+	case setElementKindUint:
+		u, err := n.Uint64()
+		if err != nil {
+			return t, err
+		}
+		if rv.OverflowUint(u) {
+			return t, errors.Join(ErrValueOverflowsSetElement, fmt.Errorf("%v overflows %T", n, t))
+		}
+		rv.SetUint(u)
+	case setElementKindFloat:
+		f, err := n.Float64()
+		if err != nil {
+			return t, err
+		}
+		return setElementFromFloat64[T](f)
+	default:
+		return t, fmt.Errorf("incompatible %T and %T", t, n)
+	}
+	return t, nil
+}
+
+// isRepresentableFloat reports whether the float64 is represented by the float of the bit size without loss of precision.
+// The value is also representable if it is the nearest float64 to the decimal that the narrower float represents,
+// such as 0.1 for float32.
+func isRepresentableFloat(v float64, bits int) bool {
+	n := float64(float32(v))
+	if n == v {
+		return true
+	}
+	return strconv.FormatFloat(n, 'g', -1, bits) == strconv.FormatFloat(v, 'g', -1, 64)
 }
 
-func isIntSetCompatible(value interface{}) (compatible bool) {
-	if _, ok := value.([]int); ok {
+// formatSetNumber formats the element of the numeric set as a DynamoDB number.
+func formatSetNumber[T SetSupportable](v T) string {
+	rv := reflect.ValueOf(v)
+	switch setElementKindOf[T]() {
+	case setElementKindInt:
+		return strconv.FormatInt(rv.Int(), 10)
+	case setElementKindUint:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case setElementKindFloat:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits())
+	}
+	return rv.String()
+}
+
+func isCompatibleWithSet[T SetSupportable](value interface{}) (compatible bool) {
+	if _, ok := value.([]T); ok {
 		compatible = true
 		return
 	}
+	switch setElementKindOf[T]() {
+	case setElementKindInt, setElementKindUint, setElementKindFloat:
+		compatible = isNumericSetCompatible[T](value)
+	}
+	return
+}
+
+func isNumericSetCompatible[T SetSupportable](value interface{}) (compatible bool) {
 	if value, ok := value.([]float64); ok {
 		compatible = true
 		for _, v := range value {
-			if math.Floor(v) == v {
+			if _, err := setElementFromFloat64[T](v); err == nil {
 				compatible = true
 				continue
 			}
@@ -192,25 +522,108 @@ func isIntSetCompatible(value interface{}) (compatible bool) {
 	return
 }
 
-func isStringSetCompatible(value interface{}) (compatible bool) {
-	if _, ok := value.([]string); ok {
-		compatible = true
+// setElementKind is the kind of the Set element.
+type setElementKind int
+
+const (
+	setElementKindString setElementKind = iota
+	setElementKindBinary
+	setElementKindInt
+	setElementKindUint
+	setElementKindFloat
+	setElementKindNumber
+)
+
+// numberType is the reflect.Type of Number
+var numberType = reflect.TypeOf(Number(""))
+
+// setElementKindOf returns the setElementKind of T.
+func setElementKindOf[T SetSupportable]() setElementKind {
+	rt := reflect.TypeOf((*T)(nil)).Elem()
+	if rt == numberType {
+		return setElementKindNumber
 	}
-	return
+	switch rt.Kind() {
+	case reflect.Slice:
+		return setElementKindBinary
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return setElementKindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return setElementKindUint
+	case reflect.Float32, reflect.Float64:
+		return setElementKindFloat
+	}
+	return setElementKindString
 }
 
-func isFloat64SetCompatible(value interface{}) (compatible bool) {
-	if _, ok := value.([]float64); ok {
-		compatible = true
+// canonicalize returns the de-duplicated and sorted copy of the set.
+//
+// Returns an error if the set violates the DynamoDB set constraints.
+func (s Set[T]) canonicalize() (Set[T], error) {
+	if len(s) == 0 {
+		return nil, ErrSetIsEmpty
 	}
-	return
+	result := make(Set[T], 0, len(s))
+	seen := make(map[string]struct{}, len(s))
+	for i, v := range s {
+		if err := validateSetElement(v); err != nil {
+			return nil, errors.Join(err, fmt.Errorf("element at index %d", i))
+		}
+		key := setElementKey(v)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, v)
+	}
+	slices.SortFunc(result, compareSetElement[T])
+	return result, nil
 }
 
-func isBinarySetCompatible(value interface{}) (compatible bool) {
-	if _, ok := value.([][]byte); ok {
-		compatible = true
+// validateSetElement returns an error if the element is not allowed in the DynamoDB set.
+func validateSetElement[T SetSupportable](v T) error {
+	rv := reflect.ValueOf(v)
+	switch setElementKindOf[T]() {
+	case setElementKindString:
+		if rv.Len() == 0 {
+			return ErrSetContainsEmptyString
+		}
+	case setElementKindBinary:
+		if rv.Len() == 0 {
+			return ErrSetContainsEmptyBinary
+		}
+	case setElementKindFloat:
+		if f := rv.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return ErrSetContainsInvalidNumber
+		}
+	case setElementKindNumber:
+		if n := Number(rv.String()); !n.IsValid() {
+			return errors.Join(ErrSetContainsInvalidNumber, fmt.Errorf("%q is not a number", n))
+		}
 	}
-	return
+	return nil
+}
+
+// compareSetElement compares the elements in the order of the canonical set.
+//
+// Strings and binaries are compared bytewise, and numbers are compared by its numeric value.
+func compareSetElement[T SetSupportable](a, b T) int {
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch setElementKindOf[T]() {
+	case setElementKindString:
+		return strings.Compare(ra.String(), rb.String())
+	case setElementKindBinary:
+		return bytes.Compare(ra.Bytes(), rb.Bytes())
+	case setElementKindInt:
+		return cmp.Compare(ra.Int(), rb.Int())
+	case setElementKindUint:
+		return cmp.Compare(ra.Uint(), rb.Uint())
+	case setElementKindFloat:
+		return cmp.Compare(ra.Float(), rb.Float())
+	case setElementKindNumber:
+		return Number(ra.String()).compare(Number(rb.String()))
+	}
+	return 0
 }
 
 func newSet[T SetSupportable]() Set[T] {
@@ -219,8 +632,10 @@ func newSet[T SetSupportable]() Set[T] {
 
 // compatibility check
 var (
-	_ driver.Valuer = (*List)(nil)
-	_ sql.Scanner   = (*List)(nil)
+	_ driver.Valuer              = (*List)(nil)
+	_ sql.Scanner                = (*List)(nil)
+	_ attributevalue.Marshaler   = (*List)(nil)
+	_ attributevalue.Unmarshaler = (*List)(nil)
 )
 
 // List is a DynamoDB list type.
@@ -232,9 +647,18 @@ type List []interface{}
 //
 // [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
 func (l *List) Scan(value interface{}) error {
+	return scanWithDefaultDecoder(l, value)
+}
+
+// scan assigns the value from the driver, or the types.AttributeValue, to the list.
+func (l *List) scan(value interface{}) error {
 	if len(*l) != 0 {
 		return ErrCollectionAlreadyContainsItem
 	}
+	value, err := driverValueOf(value)
+	if err != nil {
+		return err
+	}
 	sv, ok := value.([]interface{})
 	if !ok {
 		return errors.Join(ErrFailedToCast, fmt.Errorf("incompatible %T and %T", l, value))
@@ -254,6 +678,44 @@ func (l List) Value() (v driver.Value, err error) {
 	return
 }
 
+// encodeWith converts the list to a *types.AttributeValueMemberL with the Encoder.
+func (l List) encodeWith(e *Encoder) (types.AttributeValue, error) {
+	avl := make([]types.AttributeValue, 0, len(l))
+	for i, v := range l {
+		av, err := e.encode(v)
+		if err != nil {
+			return nil, withEncodePath(err, indexSegment(i), "")
+		}
+		avl = append(avl, av)
+	}
+	return &types.AttributeValueMemberL{Value: avl}, nil
+}
+
+// decodeWith assigns the list to the list. The elements are not decoded with the Decoder.
+func (l *List) decodeWith(_ *Decoder, value interface{}) error {
+	return l.scan(value)
+}
+
+// MarshalDynamoDBAttributeValue implements the [attributevalue.Marshaler] interface.
+//
+// [attributevalue.Marshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Marshaler
+func (l List) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return l.encodeWith(defaultEncoder)
+}
+
+// UnmarshalDynamoDBAttributeValue implements the [attributevalue.Unmarshaler] interface.
+//
+// The elements of the list are replaced. NULL is converted to the nil list.
+//
+// [attributevalue.Unmarshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Unmarshaler
+func (l *List) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	*l = nil
+	if isNull(av) {
+		return nil
+	}
+	return l.decodeWith(defaultDecoder.Load(), av)
+}
+
 // GormDataType returns the data type for Gorm.
 func (l *List) GormDataType() string {
 	return "L"
@@ -262,9 +724,15 @@ func (l *List) GormDataType() string {
 // resolveCollectionsNestedInList resolves nested collection type attribute.
 func resolveCollectionsNestedInList(l *List) error {
 	for i, v := range *l {
+		v, err := driverValueOf(v)
+		if err != nil {
+			*l = nil
+			return err
+		}
+		(*l)[i] = v
 		if v, ok := v.(map[string]interface{}); ok {
 			m := Map{}
-			err := m.Scan(v)
+			err := m.scan(v)
 			if err != nil {
 				*l = nil
 				return err
@@ -274,35 +742,35 @@ func resolveCollectionsNestedInList(l *List) error {
 		}
 		if isCompatibleWithSet[int](v) {
 			s := newSet[int]()
-			if err := s.Scan(v); err == nil {
+			if err := s.scan(v); err == nil {
 				(*l)[i] = s
 				continue
 			}
 		}
 		if isCompatibleWithSet[float64](v) {
 			s := newSet[float64]()
-			if err := s.Scan(v); err == nil {
+			if err := s.scan(v); err == nil {
 				(*l)[i] = s
 				continue
 			}
 		}
 		if isCompatibleWithSet[string](v) {
 			s := newSet[string]()
-			if err := s.Scan(v); err == nil {
+			if err := s.scan(v); err == nil {
 				(*l)[i] = s
 				continue
 			}
 		}
 		if isCompatibleWithSet[[]byte](v) {
 			s := newSet[[]byte]()
-			if err := s.Scan(v); err == nil {
+			if err := s.scan(v); err == nil {
 				(*l)[i] = s
 				continue
 			}
 		}
 		if v, ok := v.([]interface{}); ok {
 			il := List{}
-			err := il.Scan(v)
+			err := il.scan(v)
 			if err != nil {
 				*l = nil
 				return err
@@ -315,8 +783,10 @@ func resolveCollectionsNestedInList(l *List) error {
 
 // compatibility check
 var (
-	_ driver.Valuer = (*Map)(nil)
-	_ sql.Scanner   = (*Map)(nil)
+	_ driver.Valuer              = (*Map)(nil)
+	_ sql.Scanner                = (*Map)(nil)
+	_ attributevalue.Marshaler   = (*Map)(nil)
+	_ attributevalue.Unmarshaler = (*Map)(nil)
 )
 
 // Map is a DynamoDB map type.
@@ -328,9 +798,19 @@ type Map map[string]interface{}
 //
 // [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
 func (m *Map) Scan(value interface{}) error {
+	return scanWithDefaultDecoder(m, value)
+}
+
+// scan assigns the value from the driver, or the types.AttributeValue, to the map.
+func (m *Map) scan(value interface{}) error {
 	if len(*m) != 0 {
 		return ErrCollectionAlreadyContainsItem
 	}
+	value, err := driverValueOf(value)
+	if err != nil {
+		*m = nil
+		return err
+	}
 	mv, ok := value.(map[string]interface{})
 	if !ok {
 		*m = nil
@@ -351,6 +831,47 @@ func (m Map) Value() (v driver.Value, err error) {
 	return
 }
 
+// encodeWith converts the map to a *types.AttributeValueMemberM with the Encoder.
+func (m Map) encodeWith(e *Encoder) (types.AttributeValue, error) {
+	avm := make(map[string]types.AttributeValue, len(m))
+	for k, v := range m {
+		if o, ok := v.(optionalAttribute); ok && o.optionalState() == OptionalMissing {
+			continue
+		}
+		av, err := e.encode(v)
+		if err != nil {
+			return nil, withEncodePath(err, k, "")
+		}
+		avm[k] = av
+	}
+	return &types.AttributeValueMemberM{Value: avm}, nil
+}
+
+// decodeWith assigns the map to the map. The values are not decoded with the Decoder.
+func (m *Map) decodeWith(_ *Decoder, value interface{}) error {
+	return m.scan(value)
+}
+
+// MarshalDynamoDBAttributeValue implements the [attributevalue.Marshaler] interface.
+//
+// [attributevalue.Marshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Marshaler
+func (m Map) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return m.encodeWith(defaultEncoder)
+}
+
+// UnmarshalDynamoDBAttributeValue implements the [attributevalue.Unmarshaler] interface.
+//
+// The entries of the map are replaced. NULL is converted to the nil map.
+//
+// [attributevalue.Unmarshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Unmarshaler
+func (m *Map) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	*m = nil
+	if isNull(av) {
+		return nil
+	}
+	return m.decodeWith(defaultDecoder.Load(), av)
+}
+
 // GormDataType returns the data type for Gorm.
 func (m Map) GormDataType() string {
 	return "M"
@@ -359,9 +880,15 @@ func (m Map) GormDataType() string {
 // resolveCollectionsNestedInMap resolves nested document type attribute.
 func resolveCollectionsNestedInMap(m *Map) error {
 	for k, v := range *m {
+		v, err := driverValueOf(v)
+		if err != nil {
+			*m = nil
+			return err
+		}
+		(*m)[k] = v
 		if v, ok := v.(map[string]interface{}); ok {
 			im := Map{}
-			err := im.Scan(v)
+			err := im.scan(v)
 			if err != nil {
 				*m = nil
 				return err
@@ -371,35 +898,35 @@ func resolveCollectionsNestedInMap(m *Map) error {
 		}
 		if isCompatibleWithSet[int](v) {
 			s := newSet[int]()
-			if err := s.Scan(v); err == nil {
+			if err := s.scan(v); err == nil {
 				(*m)[k] = s
 				continue
 			}
 		}
 		if isCompatibleWithSet[float64](v) {
 			s := newSet[float64]()
-			if err := s.Scan(v); err == nil {
+			if err := s.scan(v); err == nil {
 				(*m)[k] = s
 				continue
 			}
 		}
 		if isCompatibleWithSet[string](v) {
 			s := newSet[string]()
-			if err := s.Scan(v); err == nil {
+			if err := s.scan(v); err == nil {
 				(*m)[k] = s
 				continue
 			}
 		}
 		if isCompatibleWithSet[[]byte](v) {
 			s := newSet[[]byte]()
-			if err := s.Scan(v); err == nil {
+			if err := s.scan(v); err == nil {
 				(*m)[k] = s
 				continue
 			}
 		}
 		if v, ok := v.([]interface{}); ok {
 			l := List{}
-			err := l.Scan(v)
+			err := l.scan(v)
 			if err != nil {
 				*m = nil
 				return err
@@ -412,8 +939,10 @@ func resolveCollectionsNestedInMap(m *Map) error {
 
 // compatibility check
 var (
-	_ driver.Valuer = (*TypedList[interface{}])(nil)
-	_ sql.Scanner   = (*TypedList[interface{}])(nil)
+	_ driver.Valuer              = (*TypedList[interface{}])(nil)
+	_ sql.Scanner                = (*TypedList[interface{}])(nil)
+	_ attributevalue.Marshaler   = (*TypedList[interface{}])(nil)
+	_ attributevalue.Unmarshaler = (*TypedList[interface{}])(nil)
 )
 
 // TypedList is a DynamoDB list type with type specification.
@@ -425,26 +954,25 @@ type TypedList[T any] []T
 //
 // [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
 func (l *TypedList[T]) Scan(value interface{}) error {
+	return scanWithDefaultDecoder(l, value)
+}
+
+// decodeWith assigns the list to the typed list with the Decoder.
+func (l *TypedList[T]) decodeWith(d *Decoder, value interface{}) error {
 	if len(*l) != 0 {
 		return ErrCollectionAlreadyContainsItem
 	}
-	sv, ok := value.([]interface{})
+	sv, ok := listOf(value)
 	if !ok {
 		return errors.Join(ErrFailedToCast, fmt.Errorf("incompatible %T and %T", l, value))
 	}
 	*l = slices.Grow(*l, len(sv))
-	for _, v := range sv {
-		mv, ok := v.(map[string]interface{})
-		if !ok {
-			var t T
-			return errors.Join(ErrFailedToCast, fmt.Errorf("incompatible %T and %T", t, v))
-		}
+	rt := reflect.TypeOf((*T)(nil)).Elem()
+	for i, v := range sv {
 		dest := new(T)
-		rv := reflect.ValueOf(dest)
-		rt := reflect.TypeOf(*dest)
-		err := AssignMapValueToReflectValue(rt, rv, mv)
+		err := d.decode(rt, reflect.ValueOf(dest).Elem(), v)
 		if err != nil {
-			return err
+			return errors.Join(ErrFailedToCast, withDecodePath(err, indexSegment(i), ""))
 		}
 		*l = append(*l, *dest)
 	}
@@ -454,20 +982,238 @@ func (l *TypedList[T]) Scan(value interface{}) error {
 // Value implements the [driver.Valuer] interface.
 //
 // [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
-func (l TypedList[T]) Value() (v driver.Value, err error) {
+func (l TypedList[T]) Value() (driver.Value, error) {
+	return l.encodeWith(defaultEncoder)
+}
+
+// encodeWith converts the typed list to a *types.AttributeValueMemberL with the Encoder.
+func (l TypedList[T]) encodeWith(e *Encoder) (types.AttributeValue, error) {
 	avl := &types.AttributeValueMemberL{Value: make([]types.AttributeValue, 0, len(l))}
-	for _, v := range l {
-		av, err := ToDocumentAttributeValue[*types.AttributeValueMemberM](v)
+	for i, v := range l {
+		av, err := e.encode(v)
 		if err != nil {
-			return nil, err
+			return nil, withEncodePath(err, indexSegment(i), "")
 		}
 		avl.Value = append(avl.Value, av)
 	}
-	v = avl
-	return
+	return avl, nil
+}
+
+// MarshalDynamoDBAttributeValue implements the [attributevalue.Marshaler] interface.
+//
+// [attributevalue.Marshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Marshaler
+func (l TypedList[T]) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return l.encodeWith(defaultEncoder)
+}
+
+// UnmarshalDynamoDBAttributeValue implements the [attributevalue.Unmarshaler] interface.
+//
+// The elements of the typed list are replaced. NULL is converted to the nil typed list.
+//
+// [attributevalue.Unmarshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Unmarshaler
+func (l *TypedList[T]) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	*l = nil
+	if isNull(av) {
+		return nil
+	}
+	return l.decodeWith(defaultDecoder.Load(), av)
 }
 
 // GormDataType returns the data type for Gorm.
 func (l *TypedList[T]) GormDataType() string {
 	return "L"
 }
+
+// compatibility check
+var (
+	_ driver.Valuer              = (*TypedMap[interface{}])(nil)
+	_ sql.Scanner                = (*TypedMap[interface{}])(nil)
+	_ attributevalue.Marshaler   = (*TypedMap[interface{}])(nil)
+	_ attributevalue.Unmarshaler = (*TypedMap[interface{}])(nil)
+)
+
+// TypedMap is a DynamoDB map type with type specification.
+//
+// See: https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/HowItWorks.NamingRulesDataTypes.html
+type TypedMap[V any] map[string]V
+
+// Scan implements the [sql.Scanner#Scan]
+//
+// [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
+func (m *TypedMap[V]) Scan(value interface{}) error {
+	return scanWithDefaultDecoder(m, value)
+}
+
+// decodeWith assigns the map to the typed map with the Decoder.
+func (m *TypedMap[V]) decodeWith(d *Decoder, value interface{}) error {
+	if len(*m) != 0 {
+		return ErrCollectionAlreadyContainsItem
+	}
+	mv, ok := mapOf(value)
+	if !ok {
+		return errors.Join(ErrFailedToCast, fmt.Errorf("incompatible %T and %T", m, value))
+	}
+	result := make(TypedMap[V], len(mv))
+	rt := reflect.TypeOf((*V)(nil)).Elem()
+	for k, v := range mv {
+		dest := new(V)
+		err := d.decode(rt, reflect.ValueOf(dest).Elem(), v)
+		if err != nil {
+			return errors.Join(ErrFailedToCast, withDecodePath(err, k, ""))
+		}
+		result[k] = *dest
+	}
+	*m = result
+	return nil
+}
+
+// Value implements the [driver.Valuer] interface.
+//
+// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
+func (m TypedMap[V]) Value() (driver.Value, error) {
+	return m.encodeWith(defaultEncoder)
+}
+
+// encodeWith converts the typed map to a *types.AttributeValueMemberM with the Encoder.
+func (m TypedMap[V]) encodeWith(e *Encoder) (types.AttributeValue, error) {
+	avm := &types.AttributeValueMemberM{Value: make(map[string]types.AttributeValue, len(m))}
+	for k, v := range m {
+		if o, ok := interface{}(v).(optionalAttribute); ok && o.optionalState() == OptionalMissing {
+			continue
+		}
+		av, err := e.encode(v)
+		if err != nil {
+			return nil, withEncodePath(err, k, "")
+		}
+		avm.Value[k] = av
+	}
+	return avm, nil
+}
+
+// MarshalDynamoDBAttributeValue implements the [attributevalue.Marshaler] interface.
+//
+// [attributevalue.Marshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Marshaler
+func (m TypedMap[V]) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return m.encodeWith(defaultEncoder)
+}
+
+// UnmarshalDynamoDBAttributeValue implements the [attributevalue.Unmarshaler] interface.
+//
+// The entries of the typed map are replaced. NULL is converted to the nil typed map.
+//
+// [attributevalue.Unmarshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Unmarshaler
+func (m *TypedMap[V]) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	*m = nil
+	if isNull(av) {
+		return nil
+	}
+	return m.decodeWith(defaultDecoder.Load(), av)
+}
+
+// GormDataType returns the data type for Gorm.
+func (m *TypedMap[V]) GormDataType() string {
+	return "M"
+}
+
+// compatibility check
+var (
+	_ driver.Valuer              = (*Document[struct{}])(nil)
+	_ sql.Scanner                = (*Document[struct{}])(nil)
+	_ attributevalue.Marshaler   = (*Document[struct{}])(nil)
+	_ attributevalue.Unmarshaler = (*Document[struct{}])(nil)
+)
+
+// Document is a DynamoDB map type that holds a single struct.
+//
+// T must be a struct or a pointer to a struct. Otherwise, Value and Scan return an error.
+// Use a pointer type such as Document[*T] to handle NULL.
+//
+// See: https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/HowItWorks.NamingRulesDataTypes.html
+type Document[T any] struct {
+	Data T
+}
+
+// Scan implements the [sql.Scanner#Scan]
+//
+// [sql.Scanner#Scan]: https://golang.org/pkg/database/sql/#Scanner
+func (d *Document[T]) Scan(value interface{}) error {
+	return scanWithDefaultDecoder(d, value)
+}
+
+// decodeWith assigns the map to the document with the Decoder.
+func (d *Document[T]) decodeWith(dec *Decoder, value interface{}) error {
+	var data T
+	if isNull(value) {
+		d.Data = data
+		return nil
+	}
+	mv, ok := mapOf(value)
+	if !ok {
+		return errors.Join(ErrFailedToCast, fmt.Errorf("incompatible %T and %T", d, value))
+	}
+	rv := reflect.ValueOf(&data).Elem()
+	rt := rv.Type()
+	if rt.Kind() == reflect.Pointer {
+		rv.Set(reflect.New(rt.Elem()))
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		return errors.Join(ErrFailedToCast, fmt.Errorf("incompatible %v and %T", rt, value))
+	}
+	err := dec.decodeStruct(rt, rv, mv)
+	if err != nil {
+		return err
+	}
+	d.Data = data
+	return nil
+}
+
+// Value implements the [driver.Valuer] interface.
+//
+// Returns NULL if Data is a nil pointer.
+//
+// [driver.Valuer]: https://pkg.go.dev/gorm.io/gorm#Valuer
+func (d Document[T]) Value() (driver.Value, error) {
+	return d.encodeWith(defaultEncoder)
+}
+
+// encodeWith converts the document to a *types.AttributeValueMemberM with the Encoder.
+func (d Document[T]) encodeWith(e *Encoder) (types.AttributeValue, error) {
+	rv := reflect.ValueOf(&d.Data).Elem()
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return newNullAttributeValue(), nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.Join(ErrDocumentAttributeValueIsIncompatible, fmt.Errorf("%v is not a struct", rv.Type()))
+	}
+	av, err := e.encode(rv.Interface())
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := av.(*types.AttributeValueMemberM); !ok {
+		return nil, ErrDocumentAttributeValueIsIncompatible
+	}
+	return av, nil
+}
+
+// MarshalDynamoDBAttributeValue implements the [attributevalue.Marshaler] interface.
+//
+// [attributevalue.Marshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Marshaler
+func (d Document[T]) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return d.encodeWith(defaultEncoder)
+}
+
+// UnmarshalDynamoDBAttributeValue implements the [attributevalue.Unmarshaler] interface.
+//
+// [attributevalue.Unmarshaler]: https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue#Unmarshaler
+func (d *Document[T]) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return d.decodeWith(defaultDecoder.Load(), av)
+}
+
+// GormDataType returns the data type for Gorm.
+func (d *Document[T]) GormDataType() string {
+	return "M"
+}